@@ -34,6 +34,11 @@ func main() {
 		exporter.NewRepoCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
 	)
 
+	collectors = append(
+		collectors,
+		exporter.NewUserCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
 	collectors = append(
 		collectors,
 		exporter.NewActionCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
@@ -49,6 +54,121 @@ func main() {
 		exporter.NewStorageCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
 	)
 
+	collectors = append(
+		collectors,
+		exporter.NewTrafficCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewStatsCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewProtectionCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewDependabotCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewCodeScanningCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewSecretScanningCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewDeploymentCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewDORACollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewReviewCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewIssueCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewMilestoneCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewProjectCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewTeamCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewMemberCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewAuditCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewCopilotCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewCodespacesCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewLicenseCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewAdminStatsCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewChecksCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewHookCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewCollaboratorCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
+	collectors = append(
+		collectors,
+		exporter.NewDiscussionCollector(nil, nil, nil, nil, config.Load().Target).Metrics()...,
+	)
+
 	metrics := make([]metric, 0)
 
 	metrics = append(metrics, metric{
@@ -174,6 +174,20 @@ func handler(cfg *config.Config, logger log.Logger, client *github.Client) *chi.
 		))
 	}
 
+	if cfg.Collector.Users {
+		level.Debug(logger).Log(
+			"msg", "User collector registered",
+		)
+
+		registry.MustRegister(exporter.NewUserCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
 	if cfg.Collector.Actions {
 		level.Debug(logger).Log(
 			"msg", "Action collector registered",
@@ -216,6 +230,326 @@ func handler(cfg *config.Config, logger log.Logger, client *github.Client) *chi.
 		))
 	}
 
+	if cfg.Collector.Traffic {
+		level.Debug(logger).Log(
+			"msg", "Traffic collector registered",
+		)
+
+		registry.MustRegister(exporter.NewTrafficCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Stats {
+		level.Debug(logger).Log(
+			"msg", "Stats collector registered",
+		)
+
+		registry.MustRegister(exporter.NewStatsCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Protection {
+		level.Debug(logger).Log(
+			"msg", "Protection collector registered",
+		)
+
+		registry.MustRegister(exporter.NewProtectionCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Dependabot {
+		level.Debug(logger).Log(
+			"msg", "Dependabot collector registered",
+		)
+
+		registry.MustRegister(exporter.NewDependabotCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.CodeScanning {
+		level.Debug(logger).Log(
+			"msg", "Code scanning collector registered",
+		)
+
+		registry.MustRegister(exporter.NewCodeScanningCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.SecretScanning {
+		level.Debug(logger).Log(
+			"msg", "Secret scanning collector registered",
+		)
+
+		registry.MustRegister(exporter.NewSecretScanningCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Deployments {
+		level.Debug(logger).Log(
+			"msg", "Deployment collector registered",
+		)
+
+		registry.MustRegister(exporter.NewDeploymentCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.DORA && cfg.Target.DORA.Window <= 0 {
+		level.Warn(logger).Log(
+			"msg", "DORA collector requires a positive window",
+		)
+	}
+
+	if cfg.Collector.DORA && cfg.Target.DORA.Window > 0 {
+		level.Debug(logger).Log(
+			"msg", "DORA collector registered",
+		)
+
+		registry.MustRegister(exporter.NewDORACollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Reviews {
+		level.Debug(logger).Log(
+			"msg", "Review collector registered",
+		)
+
+		registry.MustRegister(exporter.NewReviewCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Milestones {
+		level.Debug(logger).Log(
+			"msg", "Milestone collector registered",
+		)
+
+		registry.MustRegister(exporter.NewMilestoneCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Projects {
+		level.Debug(logger).Log(
+			"msg", "Project collector registered",
+		)
+
+		registry.MustRegister(exporter.NewProjectCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Teams {
+		level.Debug(logger).Log(
+			"msg", "Team collector registered",
+		)
+
+		registry.MustRegister(exporter.NewTeamCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Members {
+		level.Debug(logger).Log(
+			"msg", "Member collector registered",
+		)
+
+		registry.MustRegister(exporter.NewMemberCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Audit {
+		level.Debug(logger).Log(
+			"msg", "Audit collector registered",
+		)
+
+		registry.MustRegister(exporter.NewAuditCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Copilot {
+		level.Debug(logger).Log(
+			"msg", "Copilot collector registered",
+		)
+
+		registry.MustRegister(exporter.NewCopilotCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Codespaces {
+		level.Debug(logger).Log(
+			"msg", "Codespaces collector registered",
+		)
+
+		registry.MustRegister(exporter.NewCodespacesCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Licenses {
+		level.Debug(logger).Log(
+			"msg", "License collector registered",
+		)
+
+		registry.MustRegister(exporter.NewLicenseCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.AdminStats && cfg.Target.BaseURL == "" {
+		level.Warn(logger).Log(
+			"msg", "Admin stats collector requires an enterprise server base URL",
+		)
+	}
+
+	if cfg.Collector.AdminStats && cfg.Target.BaseURL != "" {
+		level.Debug(logger).Log(
+			"msg", "Admin stats collector registered",
+		)
+
+		registry.MustRegister(exporter.NewAdminStatsCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Checks {
+		level.Debug(logger).Log(
+			"msg", "Checks collector registered",
+		)
+
+		registry.MustRegister(exporter.NewChecksCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Hooks {
+		level.Debug(logger).Log(
+			"msg", "Hook collector registered",
+		)
+
+		registry.MustRegister(exporter.NewHookCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Collaborators {
+		level.Debug(logger).Log(
+			"msg", "Collaborator collector registered",
+		)
+
+		registry.MustRegister(exporter.NewCollaboratorCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
+	if cfg.Collector.Discussions {
+		level.Debug(logger).Log(
+			"msg", "Discussion collector registered",
+		)
+
+		registry.MustRegister(exporter.NewDiscussionCollector(
+			logger,
+			client,
+			requestFailures,
+			requestDuration,
+			cfg.Target,
+		))
+	}
+
 	level.Debug(logger).Log(
 		"msg", "Issue collector registered",
 	)
@@ -147,6 +147,13 @@ func RootFlags(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"GITHUB_EXPORTER_ORG", "GITHUB_EXPORTER_ORGS"},
 			Destination: &cfg.Target.Orgs,
 		},
+		&cli.StringSliceFlag{
+			Name:        "github.user",
+			Value:       cli.NewStringSlice(),
+			Usage:       "Users to scrape metrics from",
+			EnvVars:     []string{"GITHUB_EXPORTER_USER", "GITHUB_EXPORTER_USERS"},
+			Destination: &cfg.Target.Users,
+		},
 		&cli.StringSliceFlag{
 			Name:        "github.repo",
 			Value:       cli.NewStringSlice(),
@@ -154,6 +161,13 @@ func RootFlags(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"GITHUB_EXPORTER_REPO", "GITHUB_EXPORTER_REPOS"},
 			Destination: &cfg.Target.Repos,
 		},
+		&cli.StringSliceFlag{
+			Name:        "github.project",
+			Value:       cli.NewStringSlice(),
+			Usage:       "Projects to scrape metrics from, like owner/number",
+			EnvVars:     []string{"GITHUB_EXPORTER_PROJECT", "GITHUB_EXPORTER_PROJECTS"},
+			Destination: &cfg.Target.Projects.Targets,
+		},
 		&cli.BoolFlag{
 			Name:        "collector.orgs",
 			Value:       true,
@@ -168,6 +182,20 @@ func RootFlags(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_REPOS"},
 			Destination: &cfg.Collector.Repos,
 		},
+		&cli.BoolFlag{
+			Name:        "collector.repos.languages",
+			Value:       false,
+			Usage:       "Enable language metrics for repos, requires additional requests",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_REPOS_LANGUAGES"},
+			Destination: &cfg.Target.Languages.Enabled,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.users",
+			Value:       true,
+			Usage:       "Enable collector for users",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_USERS"},
+			Destination: &cfg.Collector.Users,
+		},
 		&cli.BoolFlag{
 			Name:        "collector.actions",
 			Value:       false,
@@ -189,5 +217,278 @@ func RootFlags(cfg *config.Config) []cli.Flag {
 			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_STORAGE"},
 			Destination: &cfg.Collector.Storage,
 		},
+		&cli.BoolFlag{
+			Name:        "collector.traffic",
+			Value:       false,
+			Usage:       "Enable collector for traffic",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_TRAFFIC"},
+			Destination: &cfg.Collector.Traffic,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.stats",
+			Value:       false,
+			Usage:       "Enable collector for stats",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_STATS"},
+			Destination: &cfg.Collector.Stats,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.protection",
+			Value:       false,
+			Usage:       "Enable collector for branch protection",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_PROTECTION"},
+			Destination: &cfg.Collector.Protection,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.dependabot",
+			Value:       false,
+			Usage:       "Enable collector for dependabot alerts",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DEPENDABOT"},
+			Destination: &cfg.Collector.Dependabot,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.code_scanning",
+			Value:       false,
+			Usage:       "Enable collector for code scanning alerts",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_CODE_SCANNING"},
+			Destination: &cfg.Collector.CodeScanning,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.secret_scanning",
+			Value:       false,
+			Usage:       "Enable collector for secret scanning alerts",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_SECRET_SCANNING"},
+			Destination: &cfg.Collector.SecretScanning,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.deployments",
+			Value:       false,
+			Usage:       "Enable collector for deployments",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DEPLOYMENTS"},
+			Destination: &cfg.Collector.Deployments,
+		},
+		&cli.DurationFlag{
+			Name:        "collector.deployments.window",
+			Value:       7 * 24 * time.Hour,
+			Usage:       "Window to count deployments within",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DEPLOYMENTS_WINDOW"},
+			Destination: &cfg.Target.Deployments.Window,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.dora",
+			Value:       false,
+			Usage:       "Enable collector for DORA metrics",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DORA"},
+			Destination: &cfg.Collector.DORA,
+		},
+		&cli.DurationFlag{
+			Name:        "collector.dora.window",
+			Value:       30 * 24 * time.Hour,
+			Usage:       "Rolling window to calculate DORA metrics within",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DORA_WINDOW"},
+			Destination: &cfg.Target.DORA.Window,
+		},
+		&cli.StringSliceFlag{
+			Name:        "collector.dora.environment",
+			Value:       cli.NewStringSlice("production"),
+			Usage:       "Environments to treat as production deployments",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DORA_ENVIRONMENT", "GITHUB_EXPORTER_COLLECTOR_DORA_ENVIRONMENTS"},
+			Destination: &cfg.Target.DORA.Environments,
+		},
+		&cli.StringFlag{
+			Name:        "collector.dora.incident_label",
+			Value:       "incident",
+			Usage:       "Issue label to identify incidents",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DORA_INCIDENT_LABEL"},
+			Destination: &cfg.Target.DORA.IncidentLabel,
+		},
+		&cli.StringSliceFlag{
+			Name:        "collector.dora.incident_labels",
+			Value:       cli.NewStringSlice(),
+			Usage:       "Issue labels to identify incidents per repo, like owner/name=label",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DORA_INCIDENT_LABELS"},
+			Destination: &cfg.Target.DORA.IncidentLabels,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.reviews",
+			Value:       false,
+			Usage:       "Enable collector for pull request reviews",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_REVIEWS"},
+			Destination: &cfg.Collector.Reviews,
+		},
+		&cli.DurationFlag{
+			Name:        "collector.reviews.window",
+			Value:       7 * 24 * time.Hour,
+			Usage:       "Window to analyze merged pull requests within",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_REVIEWS_WINDOW"},
+			Destination: &cfg.Target.Reviews.Window,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.reviews.exclude_bots",
+			Value:       false,
+			Usage:       "Exclude pull requests and reviews by bots",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_REVIEWS_EXCLUDE_BOTS"},
+			Destination: &cfg.Target.Reviews.ExcludeBots,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.issues.lifecycle",
+			Value:       false,
+			Usage:       "Enable lifecycle metrics for issues, requires additional requests",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_ISSUES_LIFECYCLE"},
+			Destination: &cfg.Target.Issues.Lifecycle,
+		},
+		&cli.DurationFlag{
+			Name:        "collector.issues.window",
+			Value:       30 * 24 * time.Hour,
+			Usage:       "Window to analyze closed and reopened issues within",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_ISSUES_WINDOW"},
+			Destination: &cfg.Target.Issues.Window,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.milestones",
+			Value:       false,
+			Usage:       "Enable collector for milestones",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_MILESTONES"},
+			Destination: &cfg.Collector.Milestones,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.projects",
+			Value:       false,
+			Usage:       "Enable collector for projects",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_PROJECTS"},
+			Destination: &cfg.Collector.Projects,
+		},
+		&cli.StringFlag{
+			Name:        "collector.projects.status_field",
+			Value:       "Status",
+			Usage:       "Name of the single select field for the item status",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_PROJECTS_STATUS_FIELD"},
+			Destination: &cfg.Target.Projects.StatusField,
+		},
+		&cli.StringFlag{
+			Name:        "collector.projects.iteration_field",
+			Value:       "Iteration",
+			Usage:       "Name of the iteration field for the item iteration",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_PROJECTS_ITERATION_FIELD"},
+			Destination: &cfg.Target.Projects.IterationField,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.teams",
+			Value:       false,
+			Usage:       "Enable collector for teams",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_TEAMS"},
+			Destination: &cfg.Collector.Teams,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.members",
+			Value:       false,
+			Usage:       "Enable collector for org members",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_MEMBERS"},
+			Destination: &cfg.Collector.Members,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.audit",
+			Value:       false,
+			Usage:       "Enable collector for audit log",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_AUDIT"},
+			Destination: &cfg.Collector.Audit,
+		},
+		&cli.StringFlag{
+			Name:        "collector.audit.state",
+			Value:       "",
+			Usage:       "Path to persist the audit log cursor between restarts",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_AUDIT_STATE"},
+			Destination: &cfg.Target.Audit.State,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.copilot",
+			Value:       false,
+			Usage:       "Enable collector for Copilot",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_COPILOT"},
+			Destination: &cfg.Collector.Copilot,
+		},
+		&cli.DurationFlag{
+			Name:        "collector.copilot.window",
+			Value:       720 * time.Hour,
+			Usage:       "Window of the last activity to consider a seat as active",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_COPILOT_WINDOW"},
+			Destination: &cfg.Target.Copilot.Window,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.codespaces",
+			Value:       false,
+			Usage:       "Enable collector for codespaces",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_CODESPACES"},
+			Destination: &cfg.Collector.Codespaces,
+		},
+		&cli.DurationFlag{
+			Name:        "collector.codespaces.idle_threshold",
+			Value:       168 * time.Hour,
+			Usage:       "Threshold since the last usage to consider a codespace as idle",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_CODESPACES_IDLE_THRESHOLD"},
+			Destination: &cfg.Target.Codespaces.IdleThreshold,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.licenses",
+			Value:       false,
+			Usage:       "Enable collector for licenses",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_LICENSES"},
+			Destination: &cfg.Collector.Licenses,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.admin_stats",
+			Value:       false,
+			Usage:       "Enable collector for admin stats, requires an enterprise server",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_ADMIN_STATS"},
+			Destination: &cfg.Collector.AdminStats,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.checks",
+			Value:       false,
+			Usage:       "Enable collector for checks",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_CHECKS"},
+			Destination: &cfg.Collector.Checks,
+		},
+		&cli.StringSliceFlag{
+			Name:        "collector.checks.name",
+			Value:       cli.NewStringSlice(),
+			Usage:       "Check runs to export the conclusion for, supports globbing",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_CHECKS_NAME", "GITHUB_EXPORTER_COLLECTOR_CHECKS_NAMES"},
+			Destination: &cfg.Target.Checks.Names,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.hooks",
+			Value:       false,
+			Usage:       "Enable collector for hooks",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_HOOKS"},
+			Destination: &cfg.Collector.Hooks,
+		},
+		&cli.DurationFlag{
+			Name:        "collector.hooks.window",
+			Value:       24 * time.Hour,
+			Usage:       "Window of hook deliveries to count",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_HOOKS_WINDOW"},
+			Destination: &cfg.Target.Hooks.Window,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.collaborators",
+			Value:       false,
+			Usage:       "Enable collector for collaborators",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_COLLABORATORS"},
+			Destination: &cfg.Collector.Collaborators,
+		},
+		&cli.BoolFlag{
+			Name:        "collector.discussions",
+			Value:       false,
+			Usage:       "Enable collector for discussions",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DISCUSSIONS"},
+			Destination: &cfg.Collector.Discussions,
+		},
+		&cli.DurationFlag{
+			Name:        "collector.discussions.window",
+			Value:       720 * time.Hour,
+			Usage:       "Window of created discussions to consider",
+			EnvVars:     []string{"GITHUB_EXPORTER_COLLECTOR_DISCUSSIONS_WINDOW"},
+			Destination: &cfg.Target.Discussions.Window,
+		},
 	}
 }
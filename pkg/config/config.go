@@ -27,17 +27,120 @@ type Target struct {
 	Insecure    bool
 	Enterprises cli.StringSlice
 	Orgs        cli.StringSlice
+	Users       cli.StringSlice
 	Repos       cli.StringSlice
 	Timeout     time.Duration
+	Languages   Languages
+	Deployments Deployments
+	DORA        DORA
+	Reviews     Reviews
+	Issues      Issues
+	Projects    Projects
+	Audit       Audit
+	Copilot     Copilot
+	Codespaces  Codespaces
+	Checks      Checks
+	Hooks       Hooks
+	Discussions Discussions
+}
+
+// Languages defines the language specific configuration.
+type Languages struct {
+	Enabled bool
+}
+
+// Deployments defines the deployment specific configuration.
+type Deployments struct {
+	Window time.Duration
+}
+
+// DORA defines the DORA specific configuration.
+type DORA struct {
+	Window         time.Duration
+	Environments   cli.StringSlice
+	IncidentLabel  string
+	IncidentLabels cli.StringSlice
+}
+
+// Reviews defines the review specific configuration.
+type Reviews struct {
+	Window      time.Duration
+	ExcludeBots bool
+}
+
+// Issues defines the issue specific configuration.
+type Issues struct {
+	Lifecycle bool
+	Window    time.Duration
+}
+
+// Projects defines the project specific configuration.
+type Projects struct {
+	Targets        cli.StringSlice
+	StatusField    string
+	IterationField string
+}
+
+// Audit defines the audit log specific configuration.
+type Audit struct {
+	State string
+}
+
+// Copilot defines the Copilot specific configuration.
+type Copilot struct {
+	Window time.Duration
+}
+
+// Codespaces defines the codespaces specific configuration.
+type Codespaces struct {
+	IdleThreshold time.Duration
+}
+
+// Checks defines the checks specific configuration.
+type Checks struct {
+	Names cli.StringSlice
+}
+
+// Hooks defines the hook specific configuration.
+type Hooks struct {
+	Window time.Duration
+}
+
+// Discussions defines the discussion specific configuration.
+type Discussions struct {
+	Window time.Duration
 }
 
 // Collector defines the collector specific configuration.
 type Collector struct {
-	Orgs     bool
-	Repos    bool
-	Actions  bool
-	Packages bool
-	Storage  bool
+	Orgs           bool
+	Repos          bool
+	Users          bool
+	Actions        bool
+	Packages       bool
+	Storage        bool
+	Traffic        bool
+	Stats          bool
+	Protection     bool
+	Dependabot     bool
+	CodeScanning   bool
+	SecretScanning bool
+	Deployments    bool
+	DORA           bool
+	Reviews        bool
+	Milestones     bool
+	Projects       bool
+	Teams          bool
+	Members        bool
+	Audit          bool
+	Copilot        bool
+	Codespaces     bool
+	Licenses       bool
+	AdminStats     bool
+	Checks         bool
+	Hooks          bool
+	Collaborators  bool
+	Discussions    bool
 }
 
 // Config is a combination of all available configurations.
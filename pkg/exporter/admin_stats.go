@@ -0,0 +1,243 @@
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+)
+
+// AdminStatsCollector collects metrics about the instance of an enterprise server.
+type AdminStatsCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Repos      *prometheus.Desc
+	Hooks      *prometheus.Desc
+	Pages      *prometheus.Desc
+	Orgs       *prometheus.Desc
+	Users      *prometheus.Desc
+	Pulls      *prometheus.Desc
+	Issues     *prometheus.Desc
+	Milestones *prometheus.Desc
+	Gists      *prometheus.Desc
+	Comments   *prometheus.Desc
+}
+
+// NewAdminStatsCollector returns a new AdminStatsCollector.
+func NewAdminStatsCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *AdminStatsCollector {
+	if failures != nil {
+		failures.WithLabelValues("admin_stats").Add(0)
+	}
+
+	labels := []string{"kind"}
+	return &AdminStatsCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "admin_stats"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Repos: prometheus.NewDesc(
+			"github_admin_stats_repos",
+			"Number of repositories, pushes and wikis on the instance",
+			labels,
+			nil,
+		),
+		Hooks: prometheus.NewDesc(
+			"github_admin_stats_hooks",
+			"Number of hooks on the instance",
+			labels,
+			nil,
+		),
+		Pages: prometheus.NewDesc(
+			"github_admin_stats_pages",
+			"Number of pages on the instance",
+			labels,
+			nil,
+		),
+		Orgs: prometheus.NewDesc(
+			"github_admin_stats_orgs",
+			"Number of orgs, teams and team members on the instance",
+			labels,
+			nil,
+		),
+		Users: prometheus.NewDesc(
+			"github_admin_stats_users",
+			"Number of users on the instance",
+			labels,
+			nil,
+		),
+		Pulls: prometheus.NewDesc(
+			"github_admin_stats_pulls",
+			"Number of pull requests on the instance",
+			labels,
+			nil,
+		),
+		Issues: prometheus.NewDesc(
+			"github_admin_stats_issues",
+			"Number of issues on the instance",
+			labels,
+			nil,
+		),
+		Milestones: prometheus.NewDesc(
+			"github_admin_stats_milestones",
+			"Number of milestones on the instance",
+			labels,
+			nil,
+		),
+		Gists: prometheus.NewDesc(
+			"github_admin_stats_gists",
+			"Number of gists on the instance",
+			labels,
+			nil,
+		),
+		Comments: prometheus.NewDesc(
+			"github_admin_stats_comments",
+			"Number of comments on the instance",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *AdminStatsCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Repos,
+		c.Hooks,
+		c.Pages,
+		c.Orgs,
+		c.Users,
+		c.Pulls,
+		c.Issues,
+		c.Milestones,
+		c.Gists,
+		c.Comments,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *AdminStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Repos
+	ch <- c.Hooks
+	ch <- c.Pages
+	ch <- c.Orgs
+	ch <- c.Users
+	ch <- c.Pulls
+	ch <- c.Issues
+	ch <- c.Milestones
+	ch <- c.Gists
+	ch <- c.Comments
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *AdminStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+
+	now := time.Now()
+	record, _, err := c.client.Admin.GetAdminStats(ctx)
+	c.duration.WithLabelValues("admin_stats").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		if isNotEnabled(err) {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch admin stats, token requires site_admin permission",
+				"err", err,
+			)
+		} else {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch admin stats",
+				"err", err,
+			)
+		}
+
+		c.failures.WithLabelValues("admin_stats").Inc()
+		return
+	}
+
+	if stats := record.Repos; stats != nil {
+		c.gauge(ch, c.Repos, "total", stats.TotalRepos)
+		c.gauge(ch, c.Repos, "root", stats.RootRepos)
+		c.gauge(ch, c.Repos, "fork", stats.ForkRepos)
+		c.gauge(ch, c.Repos, "org", stats.OrgRepos)
+		c.gauge(ch, c.Repos, "pushes", stats.TotalPushes)
+		c.gauge(ch, c.Repos, "wikis", stats.TotalWikis)
+	}
+
+	if stats := record.Hooks; stats != nil {
+		c.gauge(ch, c.Hooks, "total", stats.TotalHooks)
+		c.gauge(ch, c.Hooks, "active", stats.ActiveHooks)
+		c.gauge(ch, c.Hooks, "inactive", stats.InactiveHooks)
+	}
+
+	if stats := record.Pages; stats != nil {
+		c.gauge(ch, c.Pages, "total", stats.TotalPages)
+	}
+
+	if stats := record.Orgs; stats != nil {
+		c.gauge(ch, c.Orgs, "total", stats.TotalOrgs)
+		c.gauge(ch, c.Orgs, "disabled", stats.DisabledOrgs)
+		c.gauge(ch, c.Orgs, "teams", stats.TotalTeams)
+		c.gauge(ch, c.Orgs, "team_members", stats.TotalTeamMembers)
+	}
+
+	if stats := record.Users; stats != nil {
+		c.gauge(ch, c.Users, "total", stats.TotalUsers)
+		c.gauge(ch, c.Users, "admin", stats.AdminUsers)
+		c.gauge(ch, c.Users, "suspended", stats.SuspendedUsers)
+	}
+
+	if stats := record.Pulls; stats != nil {
+		c.gauge(ch, c.Pulls, "total", stats.TotalPulls)
+		c.gauge(ch, c.Pulls, "merged", stats.MergedPulls)
+		c.gauge(ch, c.Pulls, "mergeable", stats.MergablePulls)
+		c.gauge(ch, c.Pulls, "unmergeable", stats.UnmergablePulls)
+	}
+
+	if stats := record.Issues; stats != nil {
+		c.gauge(ch, c.Issues, "total", stats.TotalIssues)
+		c.gauge(ch, c.Issues, "open", stats.OpenIssues)
+		c.gauge(ch, c.Issues, "closed", stats.ClosedIssues)
+	}
+
+	if stats := record.Milestones; stats != nil {
+		c.gauge(ch, c.Milestones, "total", stats.TotalMilestones)
+		c.gauge(ch, c.Milestones, "open", stats.OpenMilestones)
+		c.gauge(ch, c.Milestones, "closed", stats.ClosedMilestones)
+	}
+
+	if stats := record.Gists; stats != nil {
+		c.gauge(ch, c.Gists, "total", stats.TotalGists)
+		c.gauge(ch, c.Gists, "private", stats.PrivateGists)
+		c.gauge(ch, c.Gists, "public", stats.PublicGists)
+	}
+
+	if stats := record.Comments; stats != nil {
+		c.gauge(ch, c.Comments, "commit", stats.TotalCommitComments)
+		c.gauge(ch, c.Comments, "gist", stats.TotalGistComments)
+		c.gauge(ch, c.Comments, "issue", stats.TotalIssueComments)
+		c.gauge(ch, c.Comments, "pull_request", stats.TotalPullRequestComments)
+	}
+}
+
+func (c *AdminStatsCollector) gauge(ch chan<- prometheus.Metric, desc *prometheus.Desc, kind string, value *int) {
+	if value == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		desc,
+		prometheus.GaugeValue,
+		float64(*value),
+		kind,
+	)
+}
@@ -0,0 +1,294 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+)
+
+// AuditCollector collects metrics about the audit log of enterprises and orgs.
+type AuditCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	mutex   sync.Mutex
+	loaded  bool
+	cursors map[string]*auditCursor
+	counts  map[auditKey]float64
+
+	Events *prometheus.Desc
+}
+
+// NewAuditCollector returns a new AuditCollector.
+func NewAuditCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *AuditCollector {
+	if failures != nil {
+		failures.WithLabelValues("audit").Add(0)
+	}
+
+	return &AuditCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "audit"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		cursors: make(map[string]*auditCursor),
+		counts:  make(map[auditKey]float64),
+
+		Events: prometheus.NewDesc(
+			"github_audit_events_total",
+			"Number of audit log events by category, action and actor type",
+			[]string{"type", "name", "category", "action", "actor_type"},
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *AuditCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Events,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *AuditCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Events
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *AuditCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.loaded {
+		if err := c.load(); err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to load audit log state",
+				"file", c.config.Audit.State,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("audit").Inc()
+		}
+
+		c.loaded = true
+	}
+
+	for _, name := range c.config.Enterprises.Value() {
+		c.collectScope("enterprise", name, fmt.Sprintf("enterprises/%s/audit-log", name))
+	}
+
+	for _, name := range c.config.Orgs.Value() {
+		c.collectScope("org", name, fmt.Sprintf("orgs/%s/audit-log", name))
+	}
+
+	if err := c.save(); err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to store audit log state",
+			"file", c.config.Audit.State,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("audit").Inc()
+	}
+
+	for key, count := range c.counts {
+		ch <- prometheus.MustNewConstMetric(
+			c.Events,
+			prometheus.CounterValue,
+			count,
+			key.kind,
+			key.name,
+			key.category,
+			key.action,
+			key.actor,
+		)
+	}
+}
+
+// collectScope reads all audit events since the stored cursor in ascending
+// order. The cursor gets advanced with every processed event, so a timeout
+// continues at the same position with the next scrape.
+func (c *AuditCollector) collectScope(kind, name, base string) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+
+	scope := kind + "/" + name
+	cursor, ok := c.cursors[scope]
+
+	if !ok {
+		cursor = &auditCursor{
+			Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+		}
+
+		c.cursors[scope] = cursor
+	}
+
+	since := time.Unix(0, cursor.Timestamp*int64(time.Millisecond)).UTC()
+	path := fmt.Sprintf(
+		"%s?phrase=%s&order=asc&per_page=100",
+		base,
+		url.QueryEscape("created:>="+since.Format(time.RFC3339)),
+	)
+
+	for path != "" {
+		req, err := c.client.NewRequest("GET", path, nil)
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to prepare audit log request",
+				"type", kind,
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("audit").Inc()
+			return
+		}
+
+		var (
+			entries []*auditEntry
+		)
+
+		now := time.Now()
+		resp, err := c.client.Do(ctx, req, &entries)
+		c.duration.WithLabelValues("audit").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch audit log",
+				"type", kind,
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("audit").Inc()
+			return
+		}
+
+		for _, entry := range entries {
+			if !cursor.advance(entry) {
+				continue
+			}
+
+			c.counts[auditKey{
+				kind:     kind,
+				name:     name,
+				category: strings.SplitN(entry.Action, ".", 2)[0],
+				action:   entry.Action,
+				actor:    entry.actorType(),
+			}]++
+		}
+
+		path = nextPageURL(resp)
+	}
+}
+
+func (c *AuditCollector) load() error {
+	if c.config.Audit.State == "" {
+		return nil
+	}
+
+	content, err := ioutil.ReadFile(c.config.Audit.State)
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return json.Unmarshal(content, &c.cursors)
+}
+
+func (c *AuditCollector) save() error {
+	if c.config.Audit.State == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(c.cursors)
+
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(c.config.Audit.State+".tmp", content, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(c.config.Audit.State+".tmp", c.config.Audit.State)
+}
+
+type auditKey struct {
+	kind     string
+	name     string
+	category string
+	action   string
+	actor    string
+}
+
+// auditCursor stores the timestamp of the last processed event together with
+// the IDs of all events at this timestamp, the search phrase only supports
+// second precision and events can share the same timestamp.
+type auditCursor struct {
+	Timestamp int64    `json:"timestamp"`
+	Documents []string `json:"documents,omitempty"`
+}
+
+// advance moves the cursor to the given entry and reports if the entry has
+// not been processed before.
+func (a *auditCursor) advance(entry *auditEntry) bool {
+	if entry.Timestamp < a.Timestamp {
+		return false
+	}
+
+	if entry.Timestamp == a.Timestamp {
+		for _, document := range a.Documents {
+			if document == entry.DocumentID {
+				return false
+			}
+		}
+	} else {
+		a.Timestamp = entry.Timestamp
+		a.Documents = nil
+	}
+
+	a.Documents = append(a.Documents, entry.DocumentID)
+	return true
+}
+
+type auditEntry struct {
+	Action     string `json:"action"`
+	Actor      string `json:"actor"`
+	ActorIsBot bool   `json:"actor_is_bot"`
+	DocumentID string `json:"_document_id"`
+	Timestamp  int64  `json:"@timestamp"`
+}
+
+func (a *auditEntry) actorType() string {
+	switch {
+	case a.Actor == "":
+		return "none"
+	case a.ActorIsBot || strings.HasSuffix(a.Actor, "[bot]"):
+		return "bot"
+	default:
+		return "user"
+	}
+}
@@ -0,0 +1,334 @@
+package exporter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+var (
+	checkStates = []string{"success", "pending", "failure"}
+)
+
+// ChecksCollector collects metrics about the checks of the default branches.
+type ChecksCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	State      *prometheus.Desc
+	Runs       *prometheus.Desc
+	Conclusion *prometheus.Desc
+	HeadAge    *prometheus.Desc
+}
+
+// NewChecksCollector returns a new ChecksCollector.
+func NewChecksCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *ChecksCollector {
+	if failures != nil {
+		failures.WithLabelValues("checks").Add(0)
+	}
+
+	labels := []string{"owner", "name", "branch"}
+	return &ChecksCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "checks"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		State: prometheus.NewDesc(
+			"github_checks_state",
+			"Overall state of statuses and check runs for the head of the default branch",
+			append(labels, "state"),
+			nil,
+		),
+		Runs: prometheus.NewDesc(
+			"github_checks_runs",
+			"Number of check runs for the head of the default branch by conclusion",
+			append(labels, "conclusion"),
+			nil,
+		),
+		Conclusion: prometheus.NewDesc(
+			"github_checks_conclusion",
+			"Conclusion of the allowed check runs for the head of the default branch",
+			append(labels, "check", "conclusion"),
+			nil,
+		),
+		HeadAge: prometheus.NewDesc(
+			"github_checks_head_age_seconds",
+			"Age of the head commit of the default branch",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *ChecksCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.State,
+		c.Runs,
+		c.Conclusion,
+		c.HeadAge,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *ChecksCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.State
+	ch <- c.Runs
+	ch <- c.Conclusion
+	ch <- c.HeadAge
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *ChecksCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("checks").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("checks").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("checks").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			if record.DefaultBranch == nil {
+				continue
+			}
+
+			c.collectBranch(ctx, ch, owner, *record.Name, *record.DefaultBranch)
+		}
+	}
+}
+
+func (c *ChecksCollector) collectBranch(ctx context.Context, ch chan<- prometheus.Metric, owner, repo, branch string) {
+	labels := []string{
+		owner,
+		repo,
+		branch,
+	}
+
+	now := time.Now()
+	head, _, err := c.client.Repositories.GetBranch(ctx, owner, repo, branch)
+	c.duration.WithLabelValues("checks").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch branch",
+			"owner", owner,
+			"name", repo,
+			"branch", branch,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("checks").Inc()
+		return
+	}
+
+	sha := head.GetCommit().GetSHA()
+
+	if date := head.GetCommit().GetCommit().GetCommitter().Date; date != nil {
+		ch <- prometheus.MustNewConstMetric(
+			c.HeadAge,
+			prometheus.GaugeValue,
+			positiveSeconds(time.Since(*date)),
+			labels...,
+		)
+	}
+
+	now = time.Now()
+	status, _, err := c.client.Repositories.GetCombinedStatus(ctx, owner, repo, sha, &github.ListOptions{
+		PerPage: 100,
+	})
+	c.duration.WithLabelValues("checks").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch combined status",
+			"owner", owner,
+			"name", repo,
+			"branch", branch,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("checks").Inc()
+		return
+	}
+
+	now = time.Now()
+	runs, err := c.checkRuns(ctx, owner, repo, sha)
+	c.duration.WithLabelValues("checks").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch check runs",
+			"owner", owner,
+			"name", repo,
+			"branch", branch,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("checks").Inc()
+		return
+	}
+
+	state := "success"
+
+	// The combined status is always pending without any status, so it
+	// only counts if there have been statuses reported for the commit.
+	if status.GetTotalCount() > 0 {
+		state = combineState(state, status.GetState())
+	}
+
+	conclusions := make(map[string]int)
+	checks := make(map[string]string)
+
+	for _, run := range runs {
+		conclusion := run.GetConclusion()
+
+		if run.GetStatus() != "completed" {
+			conclusion = "pending"
+		}
+
+		conclusions[conclusion]++
+		state = combineState(state, checkState(conclusion))
+
+		for _, allowed := range c.config.Checks.Names.Value() {
+			if !glob.Glob(allowed, run.GetName()) {
+				continue
+			}
+
+			// Multiple workflows could define jobs with the same name, these
+			// are reduced to the worst conclusion to export a single series.
+			if current, ok := checks[run.GetName()]; !ok || combineState(checkState(current), checkState(conclusion)) != checkState(current) {
+				checks[run.GetName()] = conclusion
+			}
+
+			break
+		}
+	}
+
+	for check, conclusion := range checks {
+		ch <- prometheus.MustNewConstMetric(
+			c.Conclusion,
+			prometheus.GaugeValue,
+			1.0,
+			append(labels, check, conclusion)...,
+		)
+	}
+
+	for conclusion, count := range conclusions {
+		ch <- prometheus.MustNewConstMetric(
+			c.Runs,
+			prometheus.GaugeValue,
+			float64(count),
+			append(labels, conclusion)...,
+		)
+	}
+
+	for _, s := range checkStates {
+		ch <- prometheus.MustNewConstMetric(
+			c.State,
+			prometheus.GaugeValue,
+			boolToFloat64(s == state),
+			append(labels, s)...,
+		)
+	}
+}
+
+func (c *ChecksCollector) checkRuns(ctx context.Context, owner, repo, ref string) ([]*github.CheckRun, error) {
+	opts := &github.ListCheckRunsOptions{
+		Filter: github.String("latest"),
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var (
+		runs []*github.CheckRun
+	)
+
+	for {
+		result, resp, err := c.client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		runs = append(
+			runs,
+			result.CheckRuns...,
+		)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return runs, nil
+}
+
+// checkState maps the conclusion of a check run to a state.
+func checkState(conclusion string) string {
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return "success"
+	case "pending":
+		return "pending"
+	default:
+		return "failure"
+	}
+}
+
+// combineState returns the worse of both states, an error of the combined
+// status is handled like a failure.
+func combineState(current, state string) string {
+	switch {
+	case current == "failure" || state == "failure" || state == "error":
+		return "failure"
+	case current == "pending" || state == "pending":
+		return "pending"
+	default:
+		return "success"
+	}
+}
@@ -0,0 +1,325 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// CodeScanningCollector collects metrics about the code scanning alerts.
+type CodeScanningCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Enabled      *prometheus.Desc
+	Alerts       *prometheus.Desc
+	LastAnalysis *prometheus.Desc
+}
+
+// NewCodeScanningCollector returns a new CodeScanningCollector.
+func NewCodeScanningCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *CodeScanningCollector {
+	if failures != nil {
+		failures.WithLabelValues("code_scanning").Add(0)
+	}
+
+	labels := []string{"owner", "name"}
+	return &CodeScanningCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "code_scanning"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Enabled: prometheus.NewDesc(
+			"github_code_scanning_enabled",
+			"Show if code scanning is enabled for this repository",
+			labels,
+			nil,
+		),
+		Alerts: prometheus.NewDesc(
+			"github_code_scanning_alerts",
+			"Number of code scanning alerts by tool, severity and state",
+			append(labels, "tool", "severity", "state"),
+			nil,
+		),
+		LastAnalysis: prometheus.NewDesc(
+			"github_code_scanning_last_analysis_timestamp",
+			"Timestamp of the last code scanning analysis on the default branch",
+			append(labels, "branch", "tool"),
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *CodeScanningCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Enabled,
+		c.Alerts,
+		c.LastAnalysis,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *CodeScanningCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Enabled
+	ch <- c.Alerts
+	ch <- c.LastAnalysis
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *CodeScanningCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("code_scanning").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("code_scanning").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("code_scanning").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			labels := []string{
+				owner,
+				*record.Name,
+			}
+
+			now := time.Now()
+			alerts, err := c.alerts(ctx, owner, *record.Name)
+			c.duration.WithLabelValues("code_scanning").Observe(time.Since(now).Seconds())
+
+			if isNotEnabled(err) {
+				ch <- prometheus.MustNewConstMetric(
+					c.Enabled,
+					prometheus.GaugeValue,
+					0.0,
+					labels...,
+				)
+
+				continue
+			}
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch alerts",
+					"name", *record.FullName,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("code_scanning").Inc()
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.Enabled,
+				prometheus.GaugeValue,
+				1.0,
+				labels...,
+			)
+
+			type key struct {
+				tool     string
+				severity string
+				state    string
+			}
+
+			counts := make(map[key]int)
+
+			for _, alert := range alerts {
+				severity := alert.Rule.SecuritySeverityLevel
+
+				if severity == "" {
+					severity = alert.Rule.Severity
+				}
+
+				counts[key{
+					tool:     alert.Tool.Name,
+					severity: severity,
+					state:    alert.State,
+				}]++
+			}
+
+			for k, count := range counts {
+				ch <- prometheus.MustNewConstMetric(
+					c.Alerts,
+					prometheus.GaugeValue,
+					float64(count),
+					append(labels, k.tool, k.severity, k.state)...,
+				)
+			}
+
+			if record.DefaultBranch == nil {
+				continue
+			}
+
+			now = time.Now()
+			analyses, err := c.analyses(ctx, owner, *record.Name, *record.DefaultBranch)
+			c.duration.WithLabelValues("code_scanning").Observe(time.Since(now).Seconds())
+
+			if isNotEnabled(err) {
+				continue
+			}
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch analyses",
+					"name", *record.FullName,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("code_scanning").Inc()
+				continue
+			}
+
+			latest := make(map[string]time.Time)
+
+			for _, analysis := range analyses {
+				if analysis.CreatedAt.After(latest[analysis.Tool.Name]) {
+					latest[analysis.Tool.Name] = analysis.CreatedAt
+				}
+			}
+
+			for tool, created := range latest {
+				ch <- prometheus.MustNewConstMetric(
+					c.LastAnalysis,
+					prometheus.GaugeValue,
+					float64(created.Unix()),
+					append(labels, *record.DefaultBranch, tool)...,
+				)
+			}
+		}
+	}
+}
+
+func (c *CodeScanningCollector) alerts(ctx context.Context, owner, repo string) ([]*codeScanningAlert, error) {
+	var (
+		result []*codeScanningAlert
+	)
+
+	path := fmt.Sprintf("repos/%s/%s/code-scanning/alerts?per_page=100", owner, repo)
+
+	for path != "" {
+		req, err := c.client.NewRequest("GET", path, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]*codeScanningAlert, 0)
+		resp, err := c.client.Do(ctx, req, &records)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(
+			result,
+			records...,
+		)
+
+		path = nextPageURL(resp)
+	}
+
+	return result, nil
+}
+
+func (c *CodeScanningCollector) analyses(ctx context.Context, owner, repo, branch string) ([]*codeScanningAnalysis, error) {
+	req, err := c.client.NewRequest(
+		"GET",
+		fmt.Sprintf(
+			"repos/%s/%s/code-scanning/analyses?per_page=100&ref=%s",
+			owner,
+			repo,
+			url.QueryEscape("refs/heads/"+branch),
+		),
+		nil,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*codeScanningAnalysis, 0)
+
+	if _, err := c.client.Do(ctx, req, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+type codeScanningAlert struct {
+	Number int    `json:"number"`
+	State  string `json:"state"`
+	Rule   struct {
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+	} `json:"rule"`
+	Tool struct {
+		Name string `json:"name"`
+	} `json:"tool"`
+}
+
+type codeScanningAnalysis struct {
+	Ref       string    `json:"ref"`
+	CreatedAt time.Time `json:"created_at"`
+	Tool      struct {
+		Name string `json:"name"`
+	} `json:"tool"`
+}
+
+// isNotEnabled reports if the feature is not available for the repository,
+// GitHub responds with 403 without Advanced Security and 404 without any
+// analysis.
+func isNotEnabled(err error) bool {
+	if isNotFound(err) {
+		return true
+	}
+
+	if resp, ok := err.(*github.ErrorResponse); ok && resp.Response != nil {
+		return resp.Response.StatusCode == http.StatusForbidden
+	}
+
+	return false
+}
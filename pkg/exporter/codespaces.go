@@ -0,0 +1,334 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+)
+
+var (
+	codespacesCores = regexp.MustCompile(`(\d+)[ _-]core`)
+)
+
+// CodespacesCollector collects metrics about the codespaces.
+type CodespacesCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Active             *prometheus.Desc
+	Machines           *prometheus.Desc
+	ProvisionedStorage *prometheus.Desc
+	Idle               *prometheus.Desc
+	CoreHours          *prometheus.Desc
+}
+
+// NewCodespacesCollector returns a new CodespacesCollector.
+func NewCodespacesCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *CodespacesCollector {
+	if failures != nil {
+		failures.WithLabelValues("codespaces").Add(0)
+	}
+
+	labels := []string{"type", "name"}
+	return &CodespacesCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "codespaces"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Active: prometheus.NewDesc(
+			"github_codespaces_active",
+			"Number of running codespaces for this type",
+			labels,
+			nil,
+		),
+		Machines: prometheus.NewDesc(
+			"github_codespaces_machines",
+			"Number of codespaces for this type broken down by machine type",
+			append(labels, "machine"),
+			nil,
+		),
+		ProvisionedStorage: prometheus.NewDesc(
+			"github_codespaces_provisioned_storage_bytes",
+			"Disk capacity provisioned by the machine types of all codespaces for this type",
+			labels,
+			nil,
+		),
+		Idle: prometheus.NewDesc(
+			"github_codespaces_idle",
+			"Number of codespaces not used within the idle threshold for this type",
+			labels,
+			nil,
+		),
+		CoreHours: prometheus.NewDesc(
+			"github_codespaces_billing_core_hours",
+			"Billed compute core hours within the current month in UTC for this type",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *CodespacesCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Active,
+		c.Machines,
+		c.ProvisionedStorage,
+		c.Idle,
+		c.CoreHours,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *CodespacesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Active
+	ch <- c.Machines
+	ch <- c.ProvisionedStorage
+	ch <- c.Idle
+	ch <- c.CoreHours
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *CodespacesCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Enterprises.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		hours, err := c.coreHours(ctx, fmt.Sprintf("enterprises/%s/settings/billing/usage", name), time.Now().UTC())
+		c.duration.WithLabelValues("codespaces").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch billing",
+				"type", "enterprise",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("codespaces").Inc()
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.CoreHours,
+			prometheus.GaugeValue,
+			hours,
+			"enterprise",
+			name,
+		)
+	}
+
+	for _, name := range c.config.Orgs.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		labels := []string{
+			"org",
+			name,
+		}
+
+		now := time.Now()
+		hours, err := c.coreHours(ctx, fmt.Sprintf("organizations/%s/settings/billing/usage", name), time.Now().UTC())
+		c.duration.WithLabelValues("codespaces").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch billing",
+				"type", "org",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("codespaces").Inc()
+		} else {
+			ch <- prometheus.MustNewConstMetric(
+				c.CoreHours,
+				prometheus.GaugeValue,
+				hours,
+				labels...,
+			)
+		}
+
+		now = time.Now()
+		codespaces, err := c.codespaces(ctx, name)
+		c.duration.WithLabelValues("codespaces").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch codespaces",
+				"type", "org",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("codespaces").Inc()
+			continue
+		}
+
+		var (
+			active  int
+			idle    int
+			storage int64
+		)
+
+		machines := make(map[string]int)
+		threshold := time.Now().Add(-c.config.Codespaces.IdleThreshold)
+
+		for _, codespace := range codespaces {
+			if codespace.State == "Available" {
+				active++
+			}
+
+			if codespace.LastUsedAt.Before(threshold) {
+				idle++
+			}
+
+			storage += codespace.Machine.StorageInBytes
+			machines[codespace.Machine.Name]++
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Active,
+			prometheus.GaugeValue,
+			float64(active),
+			labels...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Idle,
+			prometheus.GaugeValue,
+			float64(idle),
+			labels...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.ProvisionedStorage,
+			prometheus.GaugeValue,
+			float64(storage),
+			labels...,
+		)
+
+		for machine, count := range machines {
+			ch <- prometheus.MustNewConstMetric(
+				c.Machines,
+				prometheus.GaugeValue,
+				float64(count),
+				append(labels, machine)...,
+			)
+		}
+	}
+}
+
+func (c *CodespacesCollector) codespaces(ctx context.Context, org string) ([]*codespace, error) {
+	var (
+		codespaces []*codespace
+	)
+
+	path := fmt.Sprintf("orgs/%s/codespaces?per_page=100", org)
+
+	for path != "" {
+		req, err := c.client.NewRequest("GET", path, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		record := &codespacesResponse{}
+		resp, err := c.client.Do(ctx, req, record)
+
+		if err != nil {
+			return nil, err
+		}
+
+		codespaces = append(
+			codespaces,
+			record.Codespaces...,
+		)
+
+		path = nextPageURL(resp)
+	}
+
+	return codespaces, nil
+}
+
+// coreHours sums up the billed compute hours of the month multiplied by the
+// cores of the machine type, which is only part of the SKU. Without year and
+// month the API would return the usage of the whole year.
+func (c *CodespacesCollector) coreHours(ctx context.Context, path string, month time.Time) (float64, error) {
+	req, err := c.client.NewRequest(
+		"GET",
+		fmt.Sprintf("%s?year=%d&month=%d", path, month.Year(), int(month.Month())),
+		nil,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	record := &billingUsageResponse{}
+
+	if _, err := c.client.Do(ctx, req, record); err != nil {
+		return 0, err
+	}
+
+	result := 0.0
+
+	for _, item := range record.UsageItems {
+		if !strings.EqualFold(item.Product, "codespaces") || !strings.EqualFold(item.UnitType, "hours") {
+			continue
+		}
+
+		match := codespacesCores.FindStringSubmatch(strings.ToLower(item.SKU))
+
+		if match == nil {
+			continue
+		}
+
+		cores, err := strconv.Atoi(match[1])
+
+		if err != nil {
+			continue
+		}
+
+		result += item.Quantity * float64(cores)
+	}
+
+	return result, nil
+}
+
+type codespacesResponse struct {
+	TotalCount int          `json:"total_count"`
+	Codespaces []*codespace `json:"codespaces"`
+}
+
+type codespace struct {
+	State      string    `json:"state"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Machine    struct {
+		Name           string `json:"name"`
+		StorageInBytes int64  `json:"storage_in_bytes"`
+	} `json:"machine"`
+}
+
+type billingUsageResponse struct {
+	UsageItems []struct {
+		Product  string  `json:"product"`
+		SKU      string  `json:"sku"`
+		Quantity float64 `json:"quantity"`
+		UnitType string  `json:"unitType"`
+	} `json:"usageItems"`
+}
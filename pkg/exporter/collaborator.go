@@ -0,0 +1,212 @@
+package exporter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// CollaboratorCollector collects metrics about the collaborators of repos.
+type CollaboratorCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Collaborators *prometheus.Desc
+}
+
+// NewCollaboratorCollector returns a new CollaboratorCollector.
+func NewCollaboratorCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *CollaboratorCollector {
+	if failures != nil {
+		failures.WithLabelValues("collaborator").Add(0)
+	}
+
+	return &CollaboratorCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "collaborator"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Collaborators: prometheus.NewDesc(
+			"github_collaborators",
+			"Number of collaborators on the repository by permission and affiliation, like direct, outside or indirect through teams, org ownership or base permission",
+			[]string{"owner", "name", "affiliation", "permission"},
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *CollaboratorCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Collaborators,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *CollaboratorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Collaborators
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *CollaboratorCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("collaborator").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("collaborator").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("collaborator").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			c.collectRepo(ctx, ch, owner, *record.Name)
+		}
+	}
+}
+
+// collectRepo splits the collaborators into outside collaborators, direct
+// collaborators which are not outside, and indirect collaborators. Indirect
+// access could be granted by a team, the org owner role or the base
+// permission of the org, the API does not tell them apart.
+func (c *CollaboratorCollector) collectRepo(ctx context.Context, ch chan<- prometheus.Metric, owner, repo string) {
+	affiliations := make(map[string][]*github.User)
+
+	for _, affiliation := range []string{"all", "direct", "outside"} {
+		now := time.Now()
+		users, err := c.collaborators(ctx, owner, repo, affiliation)
+		c.duration.WithLabelValues("collaborator").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch collaborators",
+				"owner", owner,
+				"name", repo,
+				"affiliation", affiliation,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("collaborator").Inc()
+			return
+		}
+
+		affiliations[affiliation] = users
+	}
+
+	direct := make(map[int64]bool)
+	outside := make(map[int64]bool)
+
+	for _, user := range affiliations["direct"] {
+		direct[user.GetID()] = true
+	}
+
+	for _, user := range affiliations["outside"] {
+		outside[user.GetID()] = true
+	}
+
+	type key struct {
+		affiliation string
+		permission  string
+	}
+
+	counts := make(map[key]int)
+
+	for _, user := range affiliations["all"] {
+		affiliation := "indirect"
+
+		if outside[user.GetID()] {
+			affiliation = "outside"
+		} else if direct[user.GetID()] {
+			affiliation = "direct"
+		}
+
+		counts[key{
+			affiliation: affiliation,
+			permission:  highestPermission(user.Permissions),
+		}]++
+	}
+
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			c.Collaborators,
+			prometheus.GaugeValue,
+			float64(count),
+			owner,
+			repo,
+			k.affiliation,
+			k.permission,
+		)
+	}
+}
+
+func (c *CollaboratorCollector) collaborators(ctx context.Context, owner, repo, affiliation string) ([]*github.User, error) {
+	opts := &github.ListCollaboratorsOptions{
+		Affiliation: affiliation,
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var (
+		users []*github.User
+	)
+
+	for {
+		result, resp, err := c.client.Repositories.ListCollaborators(ctx, owner, repo, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(
+			users,
+			result...,
+		)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return users, nil
+}
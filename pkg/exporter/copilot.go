@@ -0,0 +1,260 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+)
+
+// CopilotCollector collects metrics about the Copilot seats.
+type CopilotCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Seats               *prometheus.Desc
+	ActiveSeats         *prometheus.Desc
+	PendingInvitation   *prometheus.Desc
+	PendingCancellation *prometheus.Desc
+	TeamSeats           *prometheus.Desc
+}
+
+// NewCopilotCollector returns a new CopilotCollector.
+func NewCopilotCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *CopilotCollector {
+	if failures != nil {
+		failures.WithLabelValues("copilot").Add(0)
+	}
+
+	labels := []string{"type", "name"}
+	return &CopilotCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "copilot"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Seats: prometheus.NewDesc(
+			"github_copilot_billing_seats",
+			"Total Copilot seats for this type",
+			labels,
+			nil,
+		),
+		ActiveSeats: prometheus.NewDesc(
+			"github_copilot_billing_active_seats",
+			"Copilot seats with activity within the configured window for this type",
+			labels,
+			nil,
+		),
+		PendingInvitation: prometheus.NewDesc(
+			"github_copilot_billing_pending_invitation_seats",
+			"Copilot seats with a pending invitation for this type",
+			labels,
+			nil,
+		),
+		PendingCancellation: prometheus.NewDesc(
+			"github_copilot_billing_pending_cancellation_seats",
+			"Copilot seats pending cancellation at the end of the cycle for this type",
+			labels,
+			nil,
+		),
+		TeamSeats: prometheus.NewDesc(
+			"github_copilot_billing_team_seats",
+			"Copilot seats for this type broken down by assigning team",
+			append(labels, "team"),
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *CopilotCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Seats,
+		c.ActiveSeats,
+		c.PendingInvitation,
+		c.PendingCancellation,
+		c.TeamSeats,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *CopilotCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Seats
+	ch <- c.ActiveSeats
+	ch <- c.PendingInvitation
+	ch <- c.PendingCancellation
+	ch <- c.TeamSeats
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *CopilotCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Orgs.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		req, err := c.client.NewRequest(
+			"GET",
+			fmt.Sprintf("orgs/%s/copilot/billing", name),
+			nil,
+		)
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to prepare request",
+				"type", "org",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("copilot").Inc()
+			continue
+		}
+
+		record := &copilotBillingResponse{}
+		now := time.Now()
+		_, err = c.client.Do(ctx, req, record)
+		c.duration.WithLabelValues("copilot").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch billing",
+				"type", "org",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("copilot").Inc()
+			continue
+		}
+
+		labels := []string{
+			"org",
+			name,
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Seats,
+			prometheus.GaugeValue,
+			float64(record.SeatBreakdown.Total),
+			labels...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.PendingInvitation,
+			prometheus.GaugeValue,
+			float64(record.SeatBreakdown.PendingInvitation),
+			labels...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.PendingCancellation,
+			prometheus.GaugeValue,
+			float64(record.SeatBreakdown.PendingCancellation),
+			labels...,
+		)
+
+		now = time.Now()
+		seats, err := c.seats(ctx, name)
+		c.duration.WithLabelValues("copilot").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch seats",
+				"type", "org",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("copilot").Inc()
+			continue
+		}
+
+		active := 0
+		teams := make(map[string]int)
+		since := time.Now().Add(-c.config.Copilot.Window)
+
+		for _, seat := range seats {
+			if seat.LastActivityAt != nil && seat.LastActivityAt.After(since) {
+				active++
+			}
+
+			teams[seat.AssigningTeam.Slug]++
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.ActiveSeats,
+			prometheus.GaugeValue,
+			float64(active),
+			labels...,
+		)
+
+		for team, count := range teams {
+			ch <- prometheus.MustNewConstMetric(
+				c.TeamSeats,
+				prometheus.GaugeValue,
+				float64(count),
+				append(labels, team)...,
+			)
+		}
+	}
+}
+
+func (c *CopilotCollector) seats(ctx context.Context, org string) ([]*copilotSeat, error) {
+	var (
+		seats []*copilotSeat
+	)
+
+	path := fmt.Sprintf("orgs/%s/copilot/billing/seats?per_page=100", org)
+
+	for path != "" {
+		req, err := c.client.NewRequest("GET", path, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		record := &copilotSeatsResponse{}
+		resp, err := c.client.Do(ctx, req, record)
+
+		if err != nil {
+			return nil, err
+		}
+
+		seats = append(
+			seats,
+			record.Seats...,
+		)
+
+		path = nextPageURL(resp)
+	}
+
+	return seats, nil
+}
+
+type copilotBillingResponse struct {
+	SeatBreakdown struct {
+		Total               int `json:"total"`
+		PendingInvitation   int `json:"pending_invitation"`
+		PendingCancellation int `json:"pending_cancellation"`
+	} `json:"seat_breakdown"`
+}
+
+type copilotSeatsResponse struct {
+	TotalSeats int            `json:"total_seats"`
+	Seats      []*copilotSeat `json:"seats"`
+}
+
+type copilotSeat struct {
+	LastActivityAt *time.Time `json:"last_activity_at"`
+	AssigningTeam  struct {
+		Slug string `json:"slug"`
+	} `json:"assigning_team"`
+}
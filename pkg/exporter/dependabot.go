@@ -0,0 +1,297 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// DependabotCollector collects metrics about the Dependabot alerts.
+type DependabotCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Alerts         *prometheus.Desc
+	OldestCritical *prometheus.Desc
+}
+
+// NewDependabotCollector returns a new DependabotCollector.
+func NewDependabotCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *DependabotCollector {
+	if failures != nil {
+		failures.WithLabelValues("dependabot").Add(0)
+	}
+
+	labels := []string{"type", "name"}
+	return &DependabotCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "dependabot"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Alerts: prometheus.NewDesc(
+			"github_dependabot_alerts",
+			"Number of Dependabot alerts for this type by severity, ecosystem and state",
+			append(labels, "severity", "ecosystem", "state"),
+			nil,
+		),
+		OldestCritical: prometheus.NewDesc(
+			"github_dependabot_oldest_critical_age_seconds",
+			"Age of the oldest open critical Dependabot alert for this type, 0 if there is none",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *DependabotCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Alerts,
+		c.OldestCritical,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *DependabotCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Alerts
+	ch <- c.OldestCritical
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *DependabotCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Orgs.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := c.alerts(ctx, fmt.Sprintf("orgs/%s/dependabot/alerts", name))
+		c.duration.WithLabelValues("dependabot").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch alerts",
+				"type", "org",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("dependabot").Inc()
+			continue
+		}
+
+		c.export(ch, records, "org", name)
+	}
+
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("dependabot").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		repos, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("dependabot").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("dependabot").Inc()
+			continue
+		}
+
+		for _, record := range repos {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			now := time.Now()
+			records, err := c.alerts(ctx, fmt.Sprintf("repos/%s/%s/dependabot/alerts", owner, *record.Name))
+			c.duration.WithLabelValues("dependabot").Observe(time.Since(now).Seconds())
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch alerts",
+					"type", "repo",
+					"name", *record.FullName,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("dependabot").Inc()
+				continue
+			}
+
+			c.export(ch, records, "repo", fmt.Sprintf("%s/%s", owner, *record.Name))
+		}
+	}
+}
+
+func (c *DependabotCollector) export(ch chan<- prometheus.Metric, records []*dependabotAlert, kind, name string) {
+	type key struct {
+		severity  string
+		ecosystem string
+		state     string
+	}
+
+	counts := make(map[key]int)
+	oldest := time.Time{}
+
+	for _, record := range records {
+		severity := record.SecurityVulnerability.Severity
+
+		if severity == "" {
+			severity = record.SecurityAdvisory.Severity
+		}
+
+		counts[key{
+			severity:  severity,
+			ecosystem: record.Dependency.Package.Ecosystem,
+			state:     record.State,
+		}]++
+
+		if record.State == "open" && severity == "critical" {
+			if oldest.IsZero() || record.CreatedAt.Before(oldest) {
+				oldest = record.CreatedAt
+			}
+		}
+	}
+
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			c.Alerts,
+			prometheus.GaugeValue,
+			float64(count),
+			kind,
+			name,
+			k.severity,
+			k.ecosystem,
+			k.state,
+		)
+	}
+
+	age := 0.0
+	if !oldest.IsZero() {
+		age = time.Since(oldest).Seconds()
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.OldestCritical,
+		prometheus.GaugeValue,
+		age,
+		kind,
+		name,
+	)
+}
+
+func (c *DependabotCollector) alerts(ctx context.Context, path string) ([]*dependabotAlert, error) {
+	var (
+		result []*dependabotAlert
+	)
+
+	path = path + "?per_page=100"
+
+	for path != "" {
+		req, err := c.client.NewRequest("GET", path, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]*dependabotAlert, 0)
+		resp, err := c.client.Do(ctx, req, &records)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(
+			result,
+			records...,
+		)
+
+		path = nextPageURL(resp)
+	}
+
+	return result, nil
+}
+
+type dependabotAlert struct {
+	Number     int       `json:"number"`
+	State      string    `json:"state"`
+	CreatedAt  time.Time `json:"created_at"`
+	Dependency struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+	} `json:"dependency"`
+	SecurityAdvisory struct {
+		Severity string `json:"severity"`
+	} `json:"security_advisory"`
+	SecurityVulnerability struct {
+		Severity string `json:"severity"`
+	} `json:"security_vulnerability"`
+}
+
+// nextPageURL extracts the link to the next page from the response, this
+// works for APIs with cursor based pagination which are not covered by the
+// page numbers of the client.
+func nextPageURL(resp *github.Response) string {
+	if resp == nil || resp.Response == nil {
+		return ""
+	}
+
+	for _, link := range strings.Split(resp.Header.Get("Link"), ",") {
+		segments := strings.Split(strings.TrimSpace(link), ";")
+
+		if len(segments) < 2 {
+			continue
+		}
+
+		href := strings.TrimSpace(segments[0])
+
+		if !strings.HasPrefix(href, "<") || !strings.HasSuffix(href, ">") {
+			continue
+		}
+
+		for _, segment := range segments[1:] {
+			if strings.TrimSpace(segment) == `rel="next"` {
+				if _, err := url.Parse(href[1 : len(href)-1]); err != nil {
+					return ""
+				}
+
+				return href[1 : len(href)-1]
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,429 @@
+package exporter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+var (
+	deploymentStates = []string{
+		"error",
+		"failure",
+		"inactive",
+		"in_progress",
+		"queued",
+		"pending",
+		"success",
+	}
+)
+
+// DeploymentCollector collects metrics about the deployments and environments.
+type DeploymentCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Deployments            *prometheus.Desc
+	Latest                 *prometheus.Desc
+	LatestStatus           *prometheus.Desc
+	WaitTimer              *prometheus.Desc
+	RequiredReviewers      *prometheus.Desc
+	ProtectedBranches      *prometheus.Desc
+	CustomBranchPolicies   *prometheus.Desc
+	EnvironmentProtections *prometheus.Desc
+}
+
+// NewDeploymentCollector returns a new DeploymentCollector.
+func NewDeploymentCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *DeploymentCollector {
+	if failures != nil {
+		failures.WithLabelValues("deployment").Add(0)
+	}
+
+	labels := []string{"owner", "name", "environment"}
+	return &DeploymentCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "deployment"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Deployments: prometheus.NewDesc(
+			"github_deployment_count",
+			"Number of deployments to the environment within the configured window",
+			labels,
+			nil,
+		),
+		Latest: prometheus.NewDesc(
+			"github_deployment_latest_timestamp",
+			"Timestamp of the latest deployment to the environment",
+			labels,
+			nil,
+		),
+		LatestStatus: prometheus.NewDesc(
+			"github_deployment_latest_status",
+			"Show if the latest deployment to the environment is in this state",
+			append(labels, "state"),
+			nil,
+		),
+		WaitTimer: prometheus.NewDesc(
+			"github_deployment_environment_wait_timer",
+			"Minutes to wait before deployments to the environment proceed",
+			labels,
+			nil,
+		),
+		RequiredReviewers: prometheus.NewDesc(
+			"github_deployment_environment_required_reviewers",
+			"Number of required reviewers for deployments to the environment",
+			labels,
+			nil,
+		),
+		ProtectedBranches: prometheus.NewDesc(
+			"github_deployment_environment_protected_branches",
+			"Show if only protected branches can deploy to the environment",
+			labels,
+			nil,
+		),
+		CustomBranchPolicies: prometheus.NewDesc(
+			"github_deployment_environment_custom_branch_policies",
+			"Show if custom branch policies restrict deployments to the environment",
+			labels,
+			nil,
+		),
+		EnvironmentProtections: prometheus.NewDesc(
+			"github_deployment_environment_protection_rules",
+			"Number of protection rules for the environment",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *DeploymentCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Deployments,
+		c.Latest,
+		c.LatestStatus,
+		c.WaitTimer,
+		c.RequiredReviewers,
+		c.ProtectedBranches,
+		c.CustomBranchPolicies,
+		c.EnvironmentProtections,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *DeploymentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Deployments
+	ch <- c.Latest
+	ch <- c.LatestStatus
+	ch <- c.WaitTimer
+	ch <- c.RequiredReviewers
+	ch <- c.ProtectedBranches
+	ch <- c.CustomBranchPolicies
+	ch <- c.EnvironmentProtections
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *DeploymentCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("deployment").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("deployment").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("deployment").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			c.collectRepo(ctx, ch, owner, *record.Name)
+		}
+	}
+}
+
+func (c *DeploymentCollector) collectRepo(ctx context.Context, ch chan<- prometheus.Metric, owner, repo string) {
+	now := time.Now()
+	environments, _, err := c.client.Repositories.ListEnvironments(ctx, owner, repo)
+	c.duration.WithLabelValues("deployment").Observe(time.Since(now).Seconds())
+
+	if err != nil && !isNotFound(err) {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch environments",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("deployment").Inc()
+		return
+	}
+
+	known := make(map[string]bool)
+
+	if environments != nil {
+		for _, environment := range environments.Environments {
+			if environment.Name == nil {
+				continue
+			}
+
+			known[*environment.Name] = true
+			c.collectEnvironment(ch, owner, repo, environment)
+		}
+	}
+
+	since := time.Now().Add(-c.config.Deployments.Window)
+
+	now = time.Now()
+	deployments, err := deploymentsSince(ctx, c.client, owner, repo, since)
+	c.duration.WithLabelValues("deployment").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch deployments",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("deployment").Inc()
+		return
+	}
+
+	counts := make(map[string]int)
+	latest := make(map[string]*github.Deployment)
+
+	for _, deployment := range deployments {
+		environment := deployment.GetEnvironment()
+
+		if deployment.GetCreatedAt().After(since) {
+			counts[environment]++
+		}
+
+		if _, ok := latest[environment]; !ok {
+			latest[environment] = deployment
+		}
+	}
+
+	for environment := range known {
+		if _, ok := latest[environment]; ok {
+			continue
+		}
+
+		now := time.Now()
+		result, _, err := c.client.Repositories.ListDeployments(ctx, owner, repo, &github.DeploymentsListOptions{
+			Environment: environment,
+			ListOptions: github.ListOptions{
+				PerPage: 1,
+			},
+		})
+		c.duration.WithLabelValues("deployment").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch deployments",
+				"owner", owner,
+				"name", repo,
+				"environment", environment,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("deployment").Inc()
+			continue
+		}
+
+		if len(result) > 0 {
+			latest[environment] = result[0]
+		}
+	}
+
+	for environment, deployment := range latest {
+		labels := []string{
+			owner,
+			repo,
+			environment,
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Deployments,
+			prometheus.GaugeValue,
+			float64(counts[environment]),
+			labels...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Latest,
+			prometheus.GaugeValue,
+			float64(deployment.GetCreatedAt().Unix()),
+			labels...,
+		)
+
+		now := time.Now()
+		statuses, _, err := c.client.Repositories.ListDeploymentStatuses(ctx, owner, repo, deployment.GetID(), &github.ListOptions{
+			PerPage: 1,
+		})
+		c.duration.WithLabelValues("deployment").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch deployment statuses",
+				"owner", owner,
+				"name", repo,
+				"environment", environment,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("deployment").Inc()
+			continue
+		}
+
+		state := "pending"
+		if len(statuses) > 0 {
+			state = statuses[0].GetState()
+		}
+
+		for _, s := range deploymentStates {
+			ch <- prometheus.MustNewConstMetric(
+				c.LatestStatus,
+				prometheus.GaugeValue,
+				boolToFloat64(s == state),
+				append(labels, s)...,
+			)
+		}
+	}
+}
+
+func (c *DeploymentCollector) collectEnvironment(ch chan<- prometheus.Metric, owner, repo string, environment *github.Environment) {
+	labels := []string{
+		owner,
+		repo,
+		*environment.Name,
+	}
+
+	wait, reviewers := 0, 0
+	for _, rule := range environment.ProtectionRules {
+		switch rule.GetType() {
+		case "wait_timer":
+			wait = rule.GetWaitTimer()
+		case "required_reviewers":
+			reviewers = len(rule.Reviewers)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.EnvironmentProtections,
+		prometheus.GaugeValue,
+		float64(len(environment.ProtectionRules)),
+		labels...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.WaitTimer,
+		prometheus.GaugeValue,
+		float64(wait),
+		labels...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.RequiredReviewers,
+		prometheus.GaugeValue,
+		float64(reviewers),
+		labels...,
+	)
+
+	protected, custom := false, false
+	if environment.DeploymentBranchPolicy != nil {
+		protected = environment.DeploymentBranchPolicy.GetProtectedBranches()
+		custom = environment.DeploymentBranchPolicy.GetCustomBranchPolicies()
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ProtectedBranches,
+		prometheus.GaugeValue,
+		boolToFloat64(protected),
+		labels...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.CustomBranchPolicies,
+		prometheus.GaugeValue,
+		boolToFloat64(custom),
+		labels...,
+	)
+}
+
+// deploymentsSince pages through the deployments of a repository, which are
+// sorted by creation date, until it reaches deployments older than since.
+func deploymentsSince(ctx context.Context, client *github.Client, owner, repo string, since time.Time) ([]*github.Deployment, error) {
+	opts := &github.DeploymentsListOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var (
+		deployments []*github.Deployment
+	)
+
+	for {
+		result, resp, err := client.Repositories.ListDeployments(ctx, owner, repo, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		deployments = append(
+			deployments,
+			result...,
+		)
+
+		if resp.NextPage == 0 || len(result) == 0 {
+			break
+		}
+
+		if result[len(result)-1].GetCreatedAt().Before(since) {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return deployments, nil
+}
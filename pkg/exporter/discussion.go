@@ -0,0 +1,306 @@
+package exporter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+const discussionQuery = `query($owner: String!, $name: String!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    discussions(first: 100, after: $cursor, orderBy: {field: CREATED_AT, direction: DESC}) {
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+      nodes {
+        createdAt
+        category {
+          name
+          isAnswerable
+        }
+        answer {
+          createdAt
+        }
+      }
+    }
+  }
+}`
+
+// DiscussionCollector collects metrics about the discussions of repos.
+type DiscussionCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Discussions  *prometheus.Desc
+	Answered     *prometheus.Desc
+	Unanswered   *prometheus.Desc
+	TimeToAnswer *prometheus.Desc
+}
+
+// NewDiscussionCollector returns a new DiscussionCollector.
+func NewDiscussionCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *DiscussionCollector {
+	if failures != nil {
+		failures.WithLabelValues("discussion").Add(0)
+	}
+
+	labels := []string{"owner", "name", "category"}
+	return &DiscussionCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "discussion"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Discussions: prometheus.NewDesc(
+			"github_discussions",
+			"Number of discussions created within the configured window",
+			labels,
+			nil,
+		),
+		Answered: prometheus.NewDesc(
+			"github_discussions_answered",
+			"Number of answered discussions created within the configured window",
+			labels,
+			nil,
+		),
+		Unanswered: prometheus.NewDesc(
+			"github_discussions_unanswered",
+			"Number of unanswered discussions created within the configured window",
+			labels,
+			nil,
+		),
+		TimeToAnswer: prometheus.NewDesc(
+			"github_discussions_time_to_answer_seconds",
+			"Histogram of the time from creation to the answer of discussions created within the configured window",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *DiscussionCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Discussions,
+		c.Answered,
+		c.Unanswered,
+		c.TimeToAnswer,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *DiscussionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Discussions
+	ch <- c.Answered
+	ch <- c.Unanswered
+	ch <- c.TimeToAnswer
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *DiscussionCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("discussion").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("discussion").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("discussion").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			c.collectRepo(ctx, ch, owner, *record.Name)
+		}
+	}
+}
+
+func (c *DiscussionCollector) collectRepo(ctx context.Context, ch chan<- prometheus.Metric, owner, repo string) {
+	now := time.Now()
+	discussions, err := c.discussions(ctx, owner, repo, time.Now().Add(-c.config.Discussions.Window))
+	c.duration.WithLabelValues("discussion").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch discussions",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("discussion").Inc()
+		return
+	}
+
+	type stats struct {
+		answerable bool
+		total      int
+		answered   int
+		durations  []float64
+	}
+
+	categories := make(map[string]*stats)
+
+	for _, discussion := range discussions {
+		category, ok := categories[discussion.Category.Name]
+
+		if !ok {
+			category = &stats{
+				answerable: discussion.Category.IsAnswerable,
+			}
+
+			categories[discussion.Category.Name] = category
+		}
+
+		category.total++
+
+		if discussion.Answer != nil {
+			category.answered++
+			category.durations = append(category.durations, positiveSeconds(discussion.Answer.CreatedAt.Sub(discussion.CreatedAt)))
+		}
+	}
+
+	for name, category := range categories {
+		labels := []string{
+			owner,
+			repo,
+			name,
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Discussions,
+			prometheus.GaugeValue,
+			float64(category.total),
+			labels...,
+		)
+
+		if !category.answerable {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Answered,
+			prometheus.GaugeValue,
+			float64(category.answered),
+			labels...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Unanswered,
+			prometheus.GaugeValue,
+			float64(category.total-category.answered),
+			labels...,
+		)
+
+		count, sum, buckets := histogramBuckets(durationBuckets, category.durations)
+
+		ch <- prometheus.MustNewConstHistogram(
+			c.TimeToAnswer,
+			count,
+			sum,
+			buckets,
+			labels...,
+		)
+	}
+}
+
+// discussions fetches the discussions created since the given time, they are
+// ordered by creation, so it stops with the first older one.
+func (c *DiscussionCollector) discussions(ctx context.Context, owner, repo string, since time.Time) ([]*discussion, error) {
+	var (
+		discussions []*discussion
+		cursor      *string
+	)
+
+	for {
+		record := &discussionResponse{}
+
+		err := graphqlQuery(ctx, c.client, discussionQuery, map[string]interface{}{
+			"owner":  owner,
+			"name":   repo,
+			"cursor": cursor,
+		}, record)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result := record.Repository.Discussions
+
+		for _, node := range result.Nodes {
+			if node.CreatedAt.Before(since) {
+				return discussions, nil
+			}
+
+			discussions = append(discussions, node)
+		}
+
+		if !result.PageInfo.HasNextPage {
+			break
+		}
+
+		cursor = &result.PageInfo.EndCursor
+	}
+
+	return discussions, nil
+}
+
+type discussionResponse struct {
+	Repository struct {
+		Discussions struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []*discussion `json:"nodes"`
+		} `json:"discussions"`
+	} `json:"repository"`
+}
+
+type discussion struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Category  struct {
+		Name         string `json:"name"`
+		IsAnswerable bool   `json:"isAnswerable"`
+	} `json:"category"`
+	Answer *struct {
+		CreatedAt time.Time `json:"createdAt"`
+	} `json:"answer"`
+}
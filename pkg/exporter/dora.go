@@ -0,0 +1,581 @@
+package exporter
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// DORACollector collects the DORA metrics based on deployments, pull requests
+// and incident issues.
+type DORACollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	DeploymentFrequency *prometheus.Desc
+	LeadTime            *prometheus.Desc
+	ChangeFailureRate   *prometheus.Desc
+	TimeToRestore       *prometheus.Desc
+	Deployments         *prometheus.Desc
+	FailedDeployments   *prometheus.Desc
+	Incidents           *prometheus.Desc
+}
+
+// NewDORACollector returns a new DORACollector.
+func NewDORACollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *DORACollector {
+	if failures != nil {
+		failures.WithLabelValues("dora").Add(0)
+	}
+
+	labels := []string{"owner", "name", "environment"}
+	return &DORACollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "dora"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		DeploymentFrequency: prometheus.NewDesc(
+			"github_dora_deployment_frequency",
+			"Average number of successful deployments per day within the configured window",
+			labels,
+			nil,
+		),
+		LeadTime: prometheus.NewDesc(
+			"github_dora_lead_time_seconds",
+			"Average time from the first commit of a pull request to the first successful deployment containing its merge commit within the configured window",
+			labels,
+			nil,
+		),
+		ChangeFailureRate: prometheus.NewDesc(
+			"github_dora_change_failure_rate",
+			"Ratio of failed deployments to all finished deployments within the configured window",
+			labels,
+			nil,
+		),
+		TimeToRestore: prometheus.NewDesc(
+			"github_dora_time_to_restore_seconds",
+			"Average time to close incident issues closed within the configured window, only per repository as issues are not related to an environment",
+			[]string{"owner", "name"},
+			nil,
+		),
+		Deployments: prometheus.NewDesc(
+			"github_dora_deployments",
+			"Number of successful deployments within the configured window",
+			labels,
+			nil,
+		),
+		FailedDeployments: prometheus.NewDesc(
+			"github_dora_failed_deployments",
+			"Number of failed deployments within the configured window",
+			labels,
+			nil,
+		),
+		Incidents: prometheus.NewDesc(
+			"github_dora_incidents",
+			"Number of incident issues closed within the configured window, only per repository as issues are not related to an environment",
+			[]string{"owner", "name"},
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *DORACollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.DeploymentFrequency,
+		c.LeadTime,
+		c.ChangeFailureRate,
+		c.TimeToRestore,
+		c.Deployments,
+		c.FailedDeployments,
+		c.Incidents,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *DORACollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.DeploymentFrequency
+	ch <- c.LeadTime
+	ch <- c.ChangeFailureRate
+	ch <- c.TimeToRestore
+	ch <- c.Deployments
+	ch <- c.FailedDeployments
+	ch <- c.Incidents
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *DORACollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("dora").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("dora").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("dora").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			since := time.Now().Add(-c.config.DORA.Window)
+
+			c.collectDeployments(ctx, ch, owner, *record.Name, record.GetDefaultBranch(), since)
+			c.collectIncidents(ctx, ch, owner, *record.Name, since)
+		}
+	}
+}
+
+func (c *DORACollector) collectDeployments(ctx context.Context, ch chan<- prometheus.Metric, owner, repo, branch string, since time.Time) {
+	now := time.Now()
+	deployments, err := deploymentsSince(ctx, c.client, owner, repo, since)
+	c.duration.WithLabelValues("dora").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch deployments",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("dora").Inc()
+		return
+	}
+
+	successful := make(map[string][]*github.Deployment)
+	failed := make(map[string]int)
+
+	for _, deployment := range deployments {
+		if deployment.GetCreatedAt().Before(since) {
+			continue
+		}
+
+		environment := deployment.GetEnvironment()
+
+		if !c.isEnvironment(environment) {
+			continue
+		}
+
+		now := time.Now()
+		statuses, _, err := c.client.Repositories.ListDeploymentStatuses(ctx, owner, repo, deployment.GetID(), &github.ListOptions{
+			PerPage: 1,
+		})
+		c.duration.WithLabelValues("dora").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch deployment statuses",
+				"owner", owner,
+				"name", repo,
+				"environment", environment,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("dora").Inc()
+			continue
+		}
+
+		if len(statuses) == 0 {
+			continue
+		}
+
+		// GitHub marks earlier successful deployments of an environment as
+		// inactive once a newer one succeeds, so they still count as successful.
+		switch statuses[0].GetState() {
+		case "success", "inactive":
+			successful[environment] = append(successful[environment], deployment)
+		case "failure", "error":
+			failed[environment]++
+		}
+	}
+
+	var (
+		pulls []*github.PullRequest
+	)
+
+	if len(successful) > 0 {
+		now := time.Now()
+		pulls, err = mergedPullRequestsSince(ctx, c.client, owner, repo, branch, since)
+		c.duration.WithLabelValues("dora").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch pull requests",
+				"owner", owner,
+				"name", repo,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("dora").Inc()
+		}
+	}
+
+	firstCommits := make(map[int]time.Time)
+	contained := make(map[string]bool)
+
+	for _, environment := range c.config.DORA.Environments.Value() {
+		labels := []string{
+			owner,
+			repo,
+			environment,
+		}
+
+		deployments := successful[environment]
+		sort.Slice(deployments, func(i, j int) bool {
+			return deployments[i].GetCreatedAt().Before(deployments[j].GetCreatedAt().Time)
+		})
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Deployments,
+			prometheus.GaugeValue,
+			float64(len(deployments)),
+			labels...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.FailedDeployments,
+			prometheus.GaugeValue,
+			float64(failed[environment]),
+			labels...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.DeploymentFrequency,
+			prometheus.GaugeValue,
+			float64(len(deployments))/c.config.DORA.Window.Hours()*24,
+			labels...,
+		)
+
+		if finished := len(deployments) + failed[environment]; finished > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				c.ChangeFailureRate,
+				prometheus.GaugeValue,
+				float64(failed[environment])/float64(finished),
+				labels...,
+			)
+		}
+
+		total, count := 0.0, 0
+
+		for _, pull := range pulls {
+			merged := pull.GetMergedAt()
+
+			idx := sort.Search(len(deployments), func(i int) bool {
+				return !deployments[i].GetCreatedAt().Before(merged)
+			})
+
+			// The first successful deployment after the merge which contains
+			// the merge commit ships the change.
+			var (
+				shipped *github.Deployment
+			)
+
+			for _, deployment := range deployments[idx:] {
+				ok, err := c.containsCommit(ctx, owner, repo, deployment.GetSHA(), pull.GetMergeCommitSHA(), contained)
+
+				if err != nil {
+					level.Error(c.logger).Log(
+						"msg", "Failed to compare deployment with merge commit",
+						"owner", owner,
+						"name", repo,
+						"number", pull.GetNumber(),
+						"err", err,
+					)
+
+					c.failures.WithLabelValues("dora").Inc()
+					break
+				}
+
+				if ok {
+					shipped = deployment
+					break
+				}
+			}
+
+			if shipped == nil {
+				continue
+			}
+
+			first, ok := firstCommits[pull.GetNumber()]
+
+			if !ok {
+				now := time.Now()
+				first, err = c.firstCommit(ctx, owner, repo, pull.GetNumber())
+				c.duration.WithLabelValues("dora").Observe(time.Since(now).Seconds())
+
+				if err != nil {
+					level.Error(c.logger).Log(
+						"msg", "Failed to fetch pull request commits",
+						"owner", owner,
+						"name", repo,
+						"number", pull.GetNumber(),
+						"err", err,
+					)
+
+					c.failures.WithLabelValues("dora").Inc()
+					continue
+				}
+
+				firstCommits[pull.GetNumber()] = first
+			}
+
+			if first.IsZero() {
+				continue
+			}
+
+			total += shipped.GetCreatedAt().Sub(first).Seconds()
+			count++
+		}
+
+		if count > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				c.LeadTime,
+				prometheus.GaugeValue,
+				total/float64(count),
+				labels...,
+			)
+		}
+	}
+}
+
+func (c *DORACollector) collectIncidents(ctx context.Context, ch chan<- prometheus.Metric, owner, repo string, since time.Time) {
+	opts := &github.IssueListByRepoOptions{
+		State:  "closed",
+		Labels: []string{c.incidentLabel(owner, repo)},
+		Since:  since,
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	total, count := 0.0, 0
+
+	for {
+		now := time.Now()
+		issues, resp, err := c.client.Issues.ListByRepo(ctx, owner, repo, opts)
+		c.duration.WithLabelValues("dora").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch incidents",
+				"owner", owner,
+				"name", repo,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("dora").Inc()
+			return
+		}
+
+		for _, issue := range issues {
+			if issue.IsPullRequest() || issue.ClosedAt == nil || issue.CreatedAt == nil {
+				continue
+			}
+
+			if issue.ClosedAt.Before(since) {
+				continue
+			}
+
+			total += issue.ClosedAt.Sub(*issue.CreatedAt).Seconds()
+			count++
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	labels := []string{
+		owner,
+		repo,
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Incidents,
+		prometheus.GaugeValue,
+		float64(count),
+		labels...,
+	)
+
+	if count > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			c.TimeToRestore,
+			prometheus.GaugeValue,
+			total/float64(count),
+			labels...,
+		)
+	}
+}
+
+func (c *DORACollector) firstCommit(ctx context.Context, owner, repo string, number int) (time.Time, error) {
+	commits, _, err := c.client.PullRequests.ListCommits(ctx, owner, repo, number, &github.ListOptions{
+		PerPage: 100,
+	})
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	first := time.Time{}
+
+	for _, commit := range commits {
+		if commit.Commit == nil || commit.Commit.Author == nil || commit.Commit.Author.Date == nil {
+			continue
+		}
+
+		if first.IsZero() || commit.Commit.Author.Date.Before(first) {
+			first = *commit.Commit.Author.Date
+		}
+	}
+
+	return first, nil
+}
+
+// containsCommit checks if the deployed ref contains the given commit, the
+// results are cached as multiple pull requests share the same deployments.
+func (c *DORACollector) containsCommit(ctx context.Context, owner, repo, ref, sha string, cache map[string]bool) (bool, error) {
+	if ref == "" || sha == "" {
+		return false, nil
+	}
+
+	if ref == sha {
+		return true, nil
+	}
+
+	key := ref + "..." + sha
+
+	if result, ok := cache[key]; ok {
+		return result, nil
+	}
+
+	now := time.Now()
+	comparison, _, err := c.client.Repositories.CompareCommits(ctx, owner, repo, sha, ref)
+	c.duration.WithLabelValues("dora").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		return false, err
+	}
+
+	switch comparison.GetStatus() {
+	case "ahead", "identical":
+		cache[key] = true
+	default:
+		cache[key] = false
+	}
+
+	return cache[key], nil
+}
+
+func (c *DORACollector) isEnvironment(environment string) bool {
+	for _, e := range c.config.DORA.Environments.Value() {
+		if e == environment {
+			return true
+		}
+	}
+
+	return false
+}
+
+// incidentLabel returns the label to identify incidents, it can be overridden
+// per repo in the format owner/name=label.
+func (c *DORACollector) incidentLabel(owner, repo string) string {
+	for _, override := range c.config.DORA.IncidentLabels.Value() {
+		parts := strings.SplitN(override, "=", 2)
+
+		if len(parts) != 2 {
+			continue
+		}
+
+		if glob.Glob(parts[0], owner+"/"+repo) {
+			return parts[1]
+		}
+	}
+
+	return c.config.DORA.IncidentLabel
+}
+
+// mergedPullRequestsSince pages through the closed pull requests against the
+// base branch, sorted by last update, and returns the ones merged after since.
+func mergedPullRequestsSince(ctx context.Context, client *github.Client, owner, repo, base string, since time.Time) ([]*github.PullRequest, error) {
+	opts := &github.PullRequestListOptions{
+		State:     "closed",
+		Base:      base,
+		Sort:      "updated",
+		Direction: "desc",
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var (
+		pulls []*github.PullRequest
+	)
+
+	for {
+		result, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pull := range result {
+			if pull.MergedAt == nil || pull.MergedAt.Before(since) {
+				continue
+			}
+
+			pulls = append(pulls, pull)
+		}
+
+		if resp.NextPage == 0 || len(result) == 0 {
+			break
+		}
+
+		if result[len(result)-1].GetUpdatedAt().Before(since) {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return pulls, nil
+}
@@ -0,0 +1,58 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v35/github"
+)
+
+type graphqlRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphqlQuery executes a query against the GraphQL API. The endpoint is
+// resolved relative to the base URL, this covers github.com and the
+// /api/v3/ prefix of GitHub Enterprise.
+func graphqlQuery(ctx context.Context, client *github.Client, query string, variables map[string]interface{}, result interface{}) error {
+	req, err := client.NewRequest(
+		"POST",
+		"../graphql",
+		&graphqlRequest{
+			Query:     query,
+			Variables: variables,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	record := &graphqlResponse{}
+
+	if _, err := client.Do(ctx, req, record); err != nil {
+		return err
+	}
+
+	if len(record.Errors) > 0 {
+		messages := make([]string, 0, len(record.Errors))
+
+		for _, e := range record.Errors {
+			messages = append(messages, e.Message)
+		}
+
+		return fmt.Errorf("graphql: %s", strings.Join(messages, ", "))
+	}
+
+	return json.Unmarshal(record.Data, result)
+}
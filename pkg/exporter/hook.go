@@ -0,0 +1,395 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// HookCollector collects metrics about the webhooks of orgs and repos.
+type HookCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Active       *prometheus.Desc
+	Events       *prometheus.Desc
+	ResponseCode *prometheus.Desc
+	Status       *prometheus.Desc
+	Deliveries   *prometheus.Desc
+}
+
+// NewHookCollector returns a new HookCollector.
+func NewHookCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *HookCollector {
+	if failures != nil {
+		failures.WithLabelValues("hook").Add(0)
+	}
+
+	labels := []string{"type", "name", "id", "host"}
+	return &HookCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "hook"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Active: prometheus.NewDesc(
+			"github_hook_active",
+			"Show if the webhook is active",
+			labels,
+			nil,
+		),
+		Events: prometheus.NewDesc(
+			"github_hook_events",
+			"Number of events the webhook is subscribed to",
+			labels,
+			nil,
+		),
+		ResponseCode: prometheus.NewDesc(
+			"github_hook_last_response_code",
+			"Status code of the last response for the webhook",
+			labels,
+			nil,
+		),
+		Status: prometheus.NewDesc(
+			"github_hook_last_response_status",
+			"Status of the last response for the webhook",
+			append(labels, "status"),
+			nil,
+		),
+		Deliveries: prometheus.NewDesc(
+			"github_hook_deliveries",
+			"Number of deliveries within the configured window for the webhook by result",
+			append(labels, "result"),
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *HookCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Active,
+		c.Events,
+		c.ResponseCode,
+		c.Status,
+		c.Deliveries,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *HookCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Active
+	ch <- c.Events
+	ch <- c.ResponseCode
+	ch <- c.Status
+	ch <- c.Deliveries
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *HookCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Orgs.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		hooks, err := c.orgHooks(ctx, name)
+		c.duration.WithLabelValues("hook").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch hooks",
+				"type", "org",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("hook").Inc()
+			continue
+		}
+
+		for _, hook := range hooks {
+			c.export(ctx, ch, hook, "org", name, fmt.Sprintf("orgs/%s/hooks/%d/deliveries", name, hook.GetID()))
+		}
+	}
+
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("hook").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		repos, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("hook").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("hook").Inc()
+			continue
+		}
+
+		for _, record := range repos {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			now := time.Now()
+			hooks, err := c.repoHooks(ctx, owner, *record.Name)
+			c.duration.WithLabelValues("hook").Observe(time.Since(now).Seconds())
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch hooks",
+					"type", "repo",
+					"name", *record.FullName,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("hook").Inc()
+				continue
+			}
+
+			for _, hook := range hooks {
+				c.export(ctx, ch, hook, "repo", fmt.Sprintf("%s/%s", owner, *record.Name), fmt.Sprintf("repos/%s/%s/hooks/%d/deliveries", owner, *record.Name, hook.GetID()))
+			}
+		}
+	}
+}
+
+func (c *HookCollector) export(ctx context.Context, ch chan<- prometheus.Metric, hook *github.Hook, kind, name, deliveries string) {
+	labels := []string{
+		kind,
+		name,
+		strconv.FormatInt(hook.GetID(), 10),
+		hookHost(hook),
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Active,
+		prometheus.GaugeValue,
+		boolToFloat64(hook.GetActive()),
+		labels...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Events,
+		prometheus.GaugeValue,
+		float64(len(hook.Events)),
+		labels...,
+	)
+
+	if code, ok := hook.LastResponse["code"].(float64); ok {
+		ch <- prometheus.MustNewConstMetric(
+			c.ResponseCode,
+			prometheus.GaugeValue,
+			code,
+			labels...,
+		)
+	}
+
+	if status, ok := hook.LastResponse["status"].(string); ok {
+		ch <- prometheus.MustNewConstMetric(
+			c.Status,
+			prometheus.GaugeValue,
+			1.0,
+			append(labels, status)...,
+		)
+	}
+
+	now := time.Now()
+	records, err := c.deliveries(ctx, deliveries, time.Now().Add(-c.config.Hooks.Window))
+	c.duration.WithLabelValues("hook").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch hook deliveries",
+			"type", kind,
+			"name", name,
+			"id", hook.GetID(),
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("hook").Inc()
+		return
+	}
+
+	success, failure := 0, 0
+
+	for _, delivery := range records {
+		if delivery.StatusCode >= 200 && delivery.StatusCode < 300 {
+			success++
+		} else {
+			failure++
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Deliveries,
+		prometheus.GaugeValue,
+		float64(success),
+		append(labels, "success")...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Deliveries,
+		prometheus.GaugeValue,
+		float64(failure),
+		append(labels, "failure")...,
+	)
+}
+
+func (c *HookCollector) orgHooks(ctx context.Context, org string) ([]*github.Hook, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var (
+		hooks []*github.Hook
+	)
+
+	for {
+		result, resp, err := c.client.Organizations.ListHooks(ctx, org, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		hooks = append(
+			hooks,
+			result...,
+		)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return hooks, nil
+}
+
+func (c *HookCollector) repoHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var (
+		hooks []*github.Hook
+	)
+
+	for {
+		result, resp, err := c.client.Repositories.ListHooks(ctx, owner, repo, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		hooks = append(
+			hooks,
+			result...,
+		)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return hooks, nil
+}
+
+// deliveries fetches the deliveries of a hook since the given time, the API
+// returns the latest deliveries first, so it stops with the first older one.
+func (c *HookCollector) deliveries(ctx context.Context, path string, since time.Time) ([]*hookDelivery, error) {
+	var (
+		result []*hookDelivery
+	)
+
+	path = path + "?per_page=100"
+
+	for path != "" {
+		req, err := c.client.NewRequest("GET", path, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			records []*hookDelivery
+		)
+
+		resp, err := c.client.Do(ctx, req, &records)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			if record.DeliveredAt.Before(since) {
+				return result, nil
+			}
+
+			result = append(result, record)
+		}
+
+		path = nextPageURL(resp)
+	}
+
+	return result, nil
+}
+
+// hookHost returns only the host of the target URL, the URL could contain
+// credentials or tokens which should never be exported.
+func hookHost(hook *github.Hook) string {
+	target, ok := hook.Config["url"].(string)
+
+	if !ok {
+		return ""
+	}
+
+	parsed, err := url.Parse(target)
+
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Hostname()
+}
+
+type hookDelivery struct {
+	ID          int64     `json:"id"`
+	DeliveredAt time.Time `json:"delivered_at"`
+	StatusCode  int       `json:"status_code"`
+}
@@ -13,6 +13,18 @@ import (
 	"github.com/promhippie/github_exporter/pkg/config"
 )
 
+var (
+	ageBuckets = []float64{
+		(24 * time.Hour).Seconds(),
+		(7 * 24 * time.Hour).Seconds(),
+		(30 * 24 * time.Hour).Seconds(),
+		(90 * 24 * time.Hour).Seconds(),
+		(180 * 24 * time.Hour).Seconds(),
+		(365 * 24 * time.Hour).Seconds(),
+		(730 * 24 * time.Hour).Seconds(),
+	}
+)
+
 // IssueCollector collects metrics about the GitHub issues.
 type IssueCollector struct {
 	client   *github.Client
@@ -21,7 +33,10 @@ type IssueCollector struct {
 	duration *prometheus.HistogramVec
 	config   config.Target
 
-	All *prometheus.Desc
+	All         *prometheus.Desc
+	OpenAge     *prometheus.Desc
+	TimeToClose *prometheus.Desc
+	Reopened    *prometheus.Desc
 }
 
 // NewIssueCollector returns a new IssueCollector.
@@ -42,6 +57,24 @@ func NewIssueCollector(logger log.Logger, client *github.Client, failures *prome
 			[]string{"id", "status", "locked", "title", "body", "user", "author_association", "label", "num_comments", "created_at", "updated_at", "url", "html_url", "reactions_total", "reactions_plus_one", "reactions_minus_one", "assignee"},
 			nil,
 		),
+		OpenAge: prometheus.NewDesc(
+			"github_issues_open_age_seconds",
+			"Histogram of the age of open issues by label",
+			[]string{"owner", "name", "label"},
+			nil,
+		),
+		TimeToClose: prometheus.NewDesc(
+			"github_issues_time_to_close_seconds",
+			"Histogram of the time to close issues closed within the configured window",
+			[]string{"owner", "name"},
+			nil,
+		),
+		Reopened: prometheus.NewDesc(
+			"github_issues_reopened",
+			"Number of issues reopened within the configured window",
+			[]string{"owner", "name"},
+			nil,
+		),
 	}
 }
 
@@ -49,12 +82,18 @@ func NewIssueCollector(logger log.Logger, client *github.Client, failures *prome
 func (c *IssueCollector) Metrics() []*prometheus.Desc {
 	return []*prometheus.Desc{
 		c.All,
+		c.OpenAge,
+		c.TimeToClose,
+		c.Reopened,
 	}
 }
 
 // Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
 func (c *IssueCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.All
+	ch <- c.OpenAge
+	ch <- c.TimeToClose
+	ch <- c.Reopened
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
@@ -90,8 +129,14 @@ func (c *IssueCollector) Collect(ch chan<- prometheus.Metric) {
 			continue
 		}
 
+		if c.config.Issues.Lifecycle {
+			c.collectLifecycle(ctx, ch, owner, repo)
+		}
+
 		for i, record := range issues {
-			if record == nil {
+			// The issues API includes pull requests, they are covered by the
+			// pull request collector.
+			if record == nil || record.IsPullRequest() {
 				continue
 			}
 			id := string_int64_or_empty(record.ID)
@@ -147,6 +192,189 @@ func (c *IssueCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 }
 
+func (c *IssueCollector) collectLifecycle(ctx context.Context, ch chan<- prometheus.Metric, owner, repo string) {
+	since := time.Now().Add(-c.config.Issues.Window)
+
+	open, err := c.issues(ctx, owner, repo, &github.IssueListByRepoOptions{
+		State: "open",
+	})
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch open issues",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("repo").Inc()
+	} else {
+		ages := make(map[string][]float64)
+
+		for _, record := range open {
+			age := positiveSeconds(time.Since(record.GetCreatedAt()))
+
+			if len(record.Labels) == 0 {
+				ages[""] = append(ages[""], age)
+			}
+
+			for _, label := range record.Labels {
+				ages[label.GetName()] = append(ages[label.GetName()], age)
+			}
+		}
+
+		for label, values := range ages {
+			count, sum, buckets := histogramBuckets(ageBuckets, values)
+
+			ch <- prometheus.MustNewConstHistogram(
+				c.OpenAge,
+				count,
+				sum,
+				buckets,
+				owner,
+				repo,
+				label,
+			)
+		}
+	}
+
+	closed, err := c.issues(ctx, owner, repo, &github.IssueListByRepoOptions{
+		State: "closed",
+		Since: since,
+	})
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch closed issues",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("repo").Inc()
+	} else {
+		var (
+			durations []float64
+		)
+
+		for _, record := range closed {
+			if record.ClosedAt == nil || record.ClosedAt.Before(since) {
+				continue
+			}
+
+			durations = append(durations, positiveSeconds(record.ClosedAt.Sub(record.GetCreatedAt())))
+		}
+
+		count, sum, buckets := histogramBuckets(durationBuckets, durations)
+
+		ch <- prometheus.MustNewConstHistogram(
+			c.TimeToClose,
+			count,
+			sum,
+			buckets,
+			owner,
+			repo,
+		)
+	}
+
+	reopened, err := c.reopened(ctx, owner, repo, since)
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch issue events",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("repo").Inc()
+	} else {
+		ch <- prometheus.MustNewConstMetric(
+			c.Reopened,
+			prometheus.GaugeValue,
+			float64(reopened),
+			owner,
+			repo,
+		)
+	}
+}
+
+// issues pages through the issues of a repository and drops the pull
+// requests which are included by the API.
+func (c *IssueCollector) issues(ctx context.Context, owner, repo string, opts *github.IssueListByRepoOptions) ([]*github.Issue, error) {
+	opts.PerPage = 100
+
+	var (
+		issues []*github.Issue
+	)
+
+	for {
+		now := time.Now()
+		result, resp, err := c.client.Issues.ListByRepo(ctx, owner, repo, opts)
+		c.duration.WithLabelValues("repo").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range result {
+			if record == nil || record.IsPullRequest() {
+				continue
+			}
+
+			issues = append(issues, record)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return issues, nil
+}
+
+// reopened counts the reopen events of issues since the given time, the
+// events are sorted by creation date.
+func (c *IssueCollector) reopened(ctx context.Context, owner, repo string, since time.Time) (int, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	reopened := 0
+
+	for {
+		now := time.Now()
+		events, resp, err := c.client.Issues.ListRepositoryEvents(ctx, owner, repo, opts)
+		c.duration.WithLabelValues("repo").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			return 0, err
+		}
+
+		for _, event := range events {
+			if event.CreatedAt == nil || event.CreatedAt.Before(since) {
+				return reopened, nil
+			}
+
+			if event.GetEvent() != "reopened" || event.Issue == nil || event.Issue.IsPullRequest() {
+				continue
+			}
+
+			reopened++
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return reopened, nil
+}
+
 func (c *IssueCollector) reposByOwnerAndName(ctx context.Context, owner, repo string) ([]*github.Repository, error) {
 	if strings.Contains(repo, "*") {
 		opts := &github.SearchOptions{
@@ -0,0 +1,224 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+)
+
+// LicenseCollector collects metrics about the license consumption of enterprises.
+type LicenseCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Consumed  *prometheus.Desc
+	Purchased *prometheus.Desc
+	Users     *prometheus.Desc
+	OrgSeats  *prometheus.Desc
+}
+
+// NewLicenseCollector returns a new LicenseCollector.
+func NewLicenseCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *LicenseCollector {
+	if failures != nil {
+		failures.WithLabelValues("license").Add(0)
+	}
+
+	labels := []string{"name"}
+	return &LicenseCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "license"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Consumed: prometheus.NewDesc(
+			"github_license_seats_consumed",
+			"Number of consumed license seats within the enterprise",
+			labels,
+			nil,
+		),
+		Purchased: prometheus.NewDesc(
+			"github_license_seats_purchased",
+			"Number of purchased license seats for the enterprise",
+			labels,
+			nil,
+		),
+		Users: prometheus.NewDesc(
+			"github_license_users",
+			"Number of licensed users within the enterprise by platform",
+			append(labels, "platform"),
+			nil,
+		),
+		OrgSeats: prometheus.NewDesc(
+			"github_license_org_seats",
+			"Number of licensed users within the enterprise by org membership",
+			append(labels, "org"),
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *LicenseCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Consumed,
+		c.Purchased,
+		c.Users,
+		c.OrgSeats,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *LicenseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Consumed
+	ch <- c.Purchased
+	ch <- c.Users
+	ch <- c.OrgSeats
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *LicenseCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Enterprises.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		record, err := c.licenses(ctx, name)
+		c.duration.WithLabelValues("license").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch consumed licenses",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("license").Inc()
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Consumed,
+			prometheus.GaugeValue,
+			float64(record.TotalSeatsConsumed),
+			name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Purchased,
+			prometheus.GaugeValue,
+			float64(record.TotalSeatsPurchased),
+			name,
+		)
+
+		var (
+			cloud  int
+			server int
+		)
+
+		orgs := make(map[string]int)
+
+		for _, user := range record.Users {
+			if user.GitHubComUser {
+				cloud++
+			}
+
+			if user.EnterpriseServerUser {
+				server++
+			}
+
+			seen := make(map[string]bool)
+
+			for _, role := range user.GitHubComMemberRoles {
+				org := strings.SplitN(role, ":", 2)[0]
+
+				if org == "" || seen[org] {
+					continue
+				}
+
+				seen[org] = true
+				orgs[org]++
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Users,
+			prometheus.GaugeValue,
+			float64(cloud),
+			name,
+			"ghec",
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.Users,
+			prometheus.GaugeValue,
+			float64(server),
+			name,
+			"ghes",
+		)
+
+		for org, count := range orgs {
+			ch <- prometheus.MustNewConstMetric(
+				c.OrgSeats,
+				prometheus.GaugeValue,
+				float64(count),
+				name,
+				org,
+			)
+		}
+	}
+}
+
+func (c *LicenseCollector) licenses(ctx context.Context, enterprise string) (*licenseResponse, error) {
+	result := &licenseResponse{}
+	path := fmt.Sprintf("enterprises/%s/consumed-licenses?per_page=100", enterprise)
+
+	for path != "" {
+		req, err := c.client.NewRequest("GET", path, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		record := &licenseResponse{}
+		resp, err := c.client.Do(ctx, req, record)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.TotalSeatsConsumed = record.TotalSeatsConsumed
+		result.TotalSeatsPurchased = record.TotalSeatsPurchased
+
+		result.Users = append(
+			result.Users,
+			record.Users...,
+		)
+
+		path = nextPageURL(resp)
+	}
+
+	return result, nil
+}
+
+type licenseResponse struct {
+	TotalSeatsConsumed  int            `json:"total_seats_consumed"`
+	TotalSeatsPurchased int            `json:"total_seats_purchased"`
+	Users               []*licenseUser `json:"users"`
+}
+
+type licenseUser struct {
+	GitHubComUser        bool     `json:"github_com_user"`
+	EnterpriseServerUser bool     `json:"enterprise_server_user"`
+	GitHubComMemberRoles []string `json:"github_com_member_roles"`
+}
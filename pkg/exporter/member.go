@@ -0,0 +1,296 @@
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+)
+
+// MemberCollector collects metrics about the members and invitations of orgs.
+type MemberCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Members              *prometheus.Desc
+	Admins               *prometheus.Desc
+	TwoFactorDisabled    *prometheus.Desc
+	OutsideCollaborators *prometheus.Desc
+	PendingInvitations   *prometheus.Desc
+	OldestInvitation     *prometheus.Desc
+}
+
+// NewMemberCollector returns a new MemberCollector.
+func NewMemberCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *MemberCollector {
+	if failures != nil {
+		failures.WithLabelValues("member").Add(0)
+	}
+
+	labels := []string{"name"}
+	return &MemberCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "member"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Members: prometheus.NewDesc(
+			"github_org_members",
+			"Number of members within the org",
+			labels,
+			nil,
+		),
+		Admins: prometheus.NewDesc(
+			"github_org_admins",
+			"Number of members with the admin role within the org",
+			labels,
+			nil,
+		),
+		TwoFactorDisabled: prometheus.NewDesc(
+			"github_org_members_2fa_disabled",
+			"Number of members without two-factor authentication within the org",
+			labels,
+			nil,
+		),
+		OutsideCollaborators: prometheus.NewDesc(
+			"github_org_outside_collaborators",
+			"Number of outside collaborators on repositories of the org",
+			labels,
+			nil,
+		),
+		PendingInvitations: prometheus.NewDesc(
+			"github_org_pending_invitations",
+			"Number of pending invitations for the org",
+			labels,
+			nil,
+		),
+		OldestInvitation: prometheus.NewDesc(
+			"github_org_oldest_pending_invitation_age_seconds",
+			"Age of the oldest pending invitation for the org",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *MemberCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Members,
+		c.Admins,
+		c.TwoFactorDisabled,
+		c.OutsideCollaborators,
+		c.PendingInvitations,
+		c.OldestInvitation,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *MemberCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Members
+	ch <- c.Admins
+	ch <- c.TwoFactorDisabled
+	ch <- c.OutsideCollaborators
+	ch <- c.PendingInvitations
+	ch <- c.OldestInvitation
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *MemberCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Orgs.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		for _, filter := range []struct {
+			desc   *prometheus.Desc
+			filter string
+			role   string
+		}{
+			{c.Members, "all", "all"},
+			{c.Admins, "all", "admin"},
+			{c.TwoFactorDisabled, "2fa_disabled", "all"},
+		} {
+			now := time.Now()
+			count, err := c.members(ctx, name, filter.filter, filter.role)
+			c.duration.WithLabelValues("member").Observe(time.Since(now).Seconds())
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch members",
+					"name", name,
+					"filter", filter.filter,
+					"role", filter.role,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("member").Inc()
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				filter.desc,
+				prometheus.GaugeValue,
+				float64(count),
+				name,
+			)
+		}
+
+		now := time.Now()
+		collaborators, err := c.outsideCollaborators(ctx, name)
+		c.duration.WithLabelValues("member").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch outside collaborators",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("member").Inc()
+		} else {
+			ch <- prometheus.MustNewConstMetric(
+				c.OutsideCollaborators,
+				prometheus.GaugeValue,
+				float64(collaborators),
+				name,
+			)
+		}
+
+		now = time.Now()
+		invitations, err := c.invitations(ctx, name)
+		c.duration.WithLabelValues("member").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch pending invitations",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("member").Inc()
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.PendingInvitations,
+			prometheus.GaugeValue,
+			float64(len(invitations)),
+			name,
+		)
+
+		oldest := 0.0
+
+		for _, invitation := range invitations {
+			if invitation.CreatedAt == nil {
+				continue
+			}
+
+			if age := positiveSeconds(time.Since(*invitation.CreatedAt)); age > oldest {
+				oldest = age
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.OldestInvitation,
+			prometheus.GaugeValue,
+			oldest,
+			name,
+		)
+	}
+}
+
+func (c *MemberCollector) members(ctx context.Context, org, filter, role string) (int, error) {
+	opts := &github.ListMembersOptions{
+		Filter: filter,
+		Role:   role,
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	count := 0
+
+	for {
+		result, resp, err := c.client.Organizations.ListMembers(ctx, org, opts)
+
+		if err != nil {
+			return 0, err
+		}
+
+		count += len(result)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return count, nil
+}
+
+func (c *MemberCollector) outsideCollaborators(ctx context.Context, org string) (int, error) {
+	opts := &github.ListOutsideCollaboratorsOptions{
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	count := 0
+
+	for {
+		result, resp, err := c.client.Organizations.ListOutsideCollaborators(ctx, org, opts)
+
+		if err != nil {
+			return 0, err
+		}
+
+		count += len(result)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return count, nil
+}
+
+func (c *MemberCollector) invitations(ctx context.Context, org string) ([]*github.Invitation, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var (
+		invitations []*github.Invitation
+	)
+
+	for {
+		result, resp, err := c.client.Organizations.ListPendingOrgInvitations(ctx, org, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		invitations = append(
+			invitations,
+			result...,
+		)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return invitations, nil
+}
@@ -0,0 +1,242 @@
+package exporter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// MilestoneCollector collects metrics about the open milestones.
+type MilestoneCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	OpenIssues   *prometheus.Desc
+	ClosedIssues *prometheus.Desc
+	Due          *prometheus.Desc
+	Complete     *prometheus.Desc
+	Overdue      *prometheus.Desc
+}
+
+// NewMilestoneCollector returns a new MilestoneCollector.
+func NewMilestoneCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *MilestoneCollector {
+	if failures != nil {
+		failures.WithLabelValues("milestone").Add(0)
+	}
+
+	labels := []string{"owner", "name", "milestone"}
+	return &MilestoneCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "milestone"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		OpenIssues: prometheus.NewDesc(
+			"github_milestone_open_issues",
+			"Number of open issues within the milestone",
+			labels,
+			nil,
+		),
+		ClosedIssues: prometheus.NewDesc(
+			"github_milestone_closed_issues",
+			"Number of closed issues within the milestone",
+			labels,
+			nil,
+		),
+		Due: prometheus.NewDesc(
+			"github_milestone_due_timestamp",
+			"Timestamp of the due date of the milestone",
+			labels,
+			nil,
+		),
+		Complete: prometheus.NewDesc(
+			"github_milestone_percent_complete",
+			"Percentage of closed issues within the milestone",
+			labels,
+			nil,
+		),
+		Overdue: prometheus.NewDesc(
+			"github_milestone_overdue",
+			"Show if the milestone is past its due date",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *MilestoneCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.OpenIssues,
+		c.ClosedIssues,
+		c.Due,
+		c.Complete,
+		c.Overdue,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *MilestoneCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.OpenIssues
+	ch <- c.ClosedIssues
+	ch <- c.Due
+	ch <- c.Complete
+	ch <- c.Overdue
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *MilestoneCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("milestone").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("milestone").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("milestone").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			now := time.Now()
+			milestones, err := c.milestones(ctx, owner, *record.Name)
+			c.duration.WithLabelValues("milestone").Observe(time.Since(now).Seconds())
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch milestones",
+					"name", *record.FullName,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("milestone").Inc()
+				continue
+			}
+
+			for _, milestone := range milestones {
+				labels := []string{
+					owner,
+					*record.Name,
+					milestone.GetTitle(),
+				}
+
+				open, closed := milestone.GetOpenIssues(), milestone.GetClosedIssues()
+
+				ch <- prometheus.MustNewConstMetric(
+					c.OpenIssues,
+					prometheus.GaugeValue,
+					float64(open),
+					labels...,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					c.ClosedIssues,
+					prometheus.GaugeValue,
+					float64(closed),
+					labels...,
+				)
+
+				complete := 0.0
+				if open+closed > 0 {
+					complete = float64(closed) / float64(open+closed) * 100
+				}
+
+				ch <- prometheus.MustNewConstMetric(
+					c.Complete,
+					prometheus.GaugeValue,
+					complete,
+					labels...,
+				)
+
+				if milestone.DueOn == nil {
+					continue
+				}
+
+				ch <- prometheus.MustNewConstMetric(
+					c.Due,
+					prometheus.GaugeValue,
+					float64(milestone.DueOn.Unix()),
+					labels...,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					c.Overdue,
+					prometheus.GaugeValue,
+					boolToFloat64(milestone.DueOn.Before(time.Now())),
+					labels...,
+				)
+			}
+		}
+	}
+}
+
+func (c *MilestoneCollector) milestones(ctx context.Context, owner, repo string) ([]*github.Milestone, error) {
+	opts := &github.MilestoneListOptions{
+		State: "open",
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var (
+		milestones []*github.Milestone
+	)
+
+	for {
+		result, resp, err := c.client.Issues.ListMilestones(ctx, owner, repo, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		milestones = append(
+			milestones,
+			result...,
+		)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return milestones, nil
+}
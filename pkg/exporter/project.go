@@ -0,0 +1,270 @@
+package exporter
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+)
+
+const projectQuery = `query($login: String!, $number: Int!, $status: String!, $iteration: String!, $cursor: String) {
+  repositoryOwner(login: $login) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        title
+        items(first: 100, after: $cursor) {
+          pageInfo {
+            hasNextPage
+            endCursor
+          }
+          nodes {
+            type
+            isArchived
+            status: fieldValueByName(name: $status) {
+              ... on ProjectV2ItemFieldSingleSelectValue {
+                name
+              }
+            }
+            iteration: fieldValueByName(name: $iteration) {
+              ... on ProjectV2ItemFieldIterationValue {
+                title
+              }
+            }
+            content {
+              ... on Issue {
+                assignees {
+                  totalCount
+                }
+              }
+              ... on PullRequest {
+                assignees {
+                  totalCount
+                }
+              }
+              ... on DraftIssue {
+                assignees {
+                  totalCount
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// ProjectCollector collects metrics about the items of projects.
+type ProjectCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Items *prometheus.Desc
+}
+
+// NewProjectCollector returns a new ProjectCollector.
+func NewProjectCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *ProjectCollector {
+	if failures != nil {
+		failures.WithLabelValues("project").Add(0)
+	}
+
+	return &ProjectCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "project"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Items: prometheus.NewDesc(
+			"github_project_items",
+			"Number of project items by type, status, iteration and assignees",
+			[]string{"owner", "number", "title", "type", "status", "iteration", "assignees"},
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *ProjectCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Items,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *ProjectCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Items
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *ProjectCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Projects.Targets.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid project name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("project").Inc()
+			continue
+		}
+
+		owner := n[0]
+		number, err := strconv.Atoi(n[1])
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Invalid project number",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("project").Inc()
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		title, items, err := c.items(ctx, owner, number)
+		c.duration.WithLabelValues("project").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch project",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("project").Inc()
+			continue
+		}
+
+		type key struct {
+			kind      string
+			status    string
+			iteration string
+			assignees string
+		}
+
+		counts := make(map[key]int)
+
+		for _, item := range items {
+			if item.IsArchived {
+				continue
+			}
+
+			assignees := "0"
+			if item.Content.Assignees.TotalCount == 1 {
+				assignees = "1"
+			} else if item.Content.Assignees.TotalCount > 1 {
+				assignees = "2+"
+			}
+
+			counts[key{
+				kind:      strings.ToLower(item.Type),
+				status:    item.Status.Name,
+				iteration: item.Iteration.Title,
+				assignees: assignees,
+			}]++
+		}
+
+		for k, count := range counts {
+			ch <- prometheus.MustNewConstMetric(
+				c.Items,
+				prometheus.GaugeValue,
+				float64(count),
+				owner,
+				strconv.Itoa(number),
+				title,
+				k.kind,
+				k.status,
+				k.iteration,
+				k.assignees,
+			)
+		}
+	}
+}
+
+func (c *ProjectCollector) items(ctx context.Context, owner string, number int) (string, []*projectItem, error) {
+	var (
+		title  string
+		items  []*projectItem
+		cursor *string
+	)
+
+	for {
+		record := &projectResponse{}
+
+		err := graphqlQuery(ctx, c.client, projectQuery, map[string]interface{}{
+			"login":     owner,
+			"number":    number,
+			"status":    c.config.Projects.StatusField,
+			"iteration": c.config.Projects.IterationField,
+			"cursor":    cursor,
+		}, record)
+
+		if err != nil {
+			return "", nil, err
+		}
+
+		project := record.RepositoryOwner.ProjectV2
+		title = project.Title
+
+		items = append(
+			items,
+			project.Items.Nodes...,
+		)
+
+		if !project.Items.PageInfo.HasNextPage {
+			break
+		}
+
+		cursor = &project.Items.PageInfo.EndCursor
+	}
+
+	return title, items, nil
+}
+
+type projectResponse struct {
+	RepositoryOwner struct {
+		ProjectV2 struct {
+			Title string `json:"title"`
+			Items struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []*projectItem `json:"nodes"`
+			} `json:"items"`
+		} `json:"projectV2"`
+	} `json:"repositoryOwner"`
+}
+
+type projectItem struct {
+	Type       string `json:"type"`
+	IsArchived bool   `json:"isArchived"`
+	Status     struct {
+		Name string `json:"name"`
+	} `json:"status"`
+	Iteration struct {
+		Title string `json:"title"`
+	} `json:"iteration"`
+	Content struct {
+		Assignees struct {
+			TotalCount int `json:"totalCount"`
+		} `json:"assignees"`
+	} `json:"content"`
+}
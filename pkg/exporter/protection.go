@@ -0,0 +1,290 @@
+package exporter
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// ProtectionCollector collects metrics about the default branch protection.
+type ProtectionCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Protected            *prometheus.Desc
+	RequiredReviews      *prometheus.Desc
+	CodeOwnerReviews     *prometheus.Desc
+	RequiredStatusChecks *prometheus.Desc
+	EnforceAdmins        *prometheus.Desc
+	SignedCommits        *prometheus.Desc
+	AllowForcePushes     *prometheus.Desc
+}
+
+// NewProtectionCollector returns a new ProtectionCollector.
+func NewProtectionCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *ProtectionCollector {
+	if failures != nil {
+		failures.WithLabelValues("protection").Add(0)
+	}
+
+	labels := []string{"owner", "name", "branch"}
+	return &ProtectionCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "protection"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Protected: prometheus.NewDesc(
+			"github_protection_protected",
+			"Show if the default branch is protected",
+			labels,
+			nil,
+		),
+		RequiredReviews: prometheus.NewDesc(
+			"github_protection_required_reviews",
+			"Number of required approving reviews on the default branch",
+			labels,
+			nil,
+		),
+		CodeOwnerReviews: prometheus.NewDesc(
+			"github_protection_code_owner_reviews",
+			"Show if code owner reviews are required on the default branch",
+			labels,
+			nil,
+		),
+		RequiredStatusChecks: prometheus.NewDesc(
+			"github_protection_required_status_checks",
+			"Number of required status checks on the default branch",
+			labels,
+			nil,
+		),
+		EnforceAdmins: prometheus.NewDesc(
+			"github_protection_enforce_admins",
+			"Show if the protection is enforced for admins on the default branch",
+			labels,
+			nil,
+		),
+		SignedCommits: prometheus.NewDesc(
+			"github_protection_signed_commits",
+			"Show if signed commits are required on the default branch",
+			labels,
+			nil,
+		),
+		AllowForcePushes: prometheus.NewDesc(
+			"github_protection_allow_force_pushes",
+			"Show if force pushes are allowed on the default branch",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *ProtectionCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Protected,
+		c.RequiredReviews,
+		c.CodeOwnerReviews,
+		c.RequiredStatusChecks,
+		c.EnforceAdmins,
+		c.SignedCommits,
+		c.AllowForcePushes,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *ProtectionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Protected
+	ch <- c.RequiredReviews
+	ch <- c.CodeOwnerReviews
+	ch <- c.RequiredStatusChecks
+	ch <- c.EnforceAdmins
+	ch <- c.SignedCommits
+	ch <- c.AllowForcePushes
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *ProtectionCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("protection").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("protection").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("protection").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			if record.DefaultBranch == nil {
+				continue
+			}
+
+			labels := []string{
+				owner,
+				*record.Name,
+				*record.DefaultBranch,
+			}
+
+			now := time.Now()
+			protection, _, err := c.client.Repositories.GetBranchProtection(ctx, owner, *record.Name, *record.DefaultBranch)
+			c.duration.WithLabelValues("protection").Observe(time.Since(now).Seconds())
+
+			if isNotProtected(err) {
+				ch <- prometheus.MustNewConstMetric(
+					c.Protected,
+					prometheus.GaugeValue,
+					0.0,
+					labels...,
+				)
+
+				continue
+			}
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch branch protection",
+					"name", *record.FullName,
+					"branch", *record.DefaultBranch,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("protection").Inc()
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.Protected,
+				prometheus.GaugeValue,
+				1.0,
+				labels...,
+			)
+
+			reviews, codeOwners := 0, false
+			if protection.RequiredPullRequestReviews != nil {
+				reviews = protection.RequiredPullRequestReviews.RequiredApprovingReviewCount
+				codeOwners = protection.RequiredPullRequestReviews.RequireCodeOwnerReviews
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.RequiredReviews,
+				prometheus.GaugeValue,
+				float64(reviews),
+				labels...,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.CodeOwnerReviews,
+				prometheus.GaugeValue,
+				boolToFloat64(codeOwners),
+				labels...,
+			)
+
+			checks := 0
+			if protection.RequiredStatusChecks != nil {
+				checks = len(protection.RequiredStatusChecks.Contexts)
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.RequiredStatusChecks,
+				prometheus.GaugeValue,
+				float64(checks),
+				labels...,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.EnforceAdmins,
+				prometheus.GaugeValue,
+				boolToFloat64(protection.EnforceAdmins != nil && protection.EnforceAdmins.Enabled),
+				labels...,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				c.AllowForcePushes,
+				prometheus.GaugeValue,
+				boolToFloat64(protection.AllowForcePushes != nil && protection.AllowForcePushes.Enabled),
+				labels...,
+			)
+
+			now = time.Now()
+			signatures, _, err := c.client.Repositories.GetSignaturesProtectedBranch(ctx, owner, *record.Name, *record.DefaultBranch)
+			c.duration.WithLabelValues("protection").Observe(time.Since(now).Seconds())
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch signature protection",
+					"name", *record.FullName,
+					"branch", *record.DefaultBranch,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("protection").Inc()
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.SignedCommits,
+				prometheus.GaugeValue,
+				boolToFloat64(signatures.GetEnabled()),
+				labels...,
+			)
+		}
+	}
+}
+
+// isNotProtected checks for the specific not found error of unprotected
+// branches, other not found errors like missing permissions are no hint for
+// the protection.
+func isNotProtected(err error) bool {
+	if resp, ok := err.(*github.ErrorResponse); ok && isNotFound(err) {
+		return resp.Message == "Branch not protected"
+	}
+
+	return false
+}
+
+func isNotFound(err error) bool {
+	if resp, ok := err.(*github.ErrorResponse); ok && resp.Response != nil {
+		return resp.Response.StatusCode == http.StatusNotFound
+	}
+
+	return false
+}
@@ -42,6 +42,10 @@ type RepoCollector struct {
 	HasPages         *prometheus.Desc
 	HasProjects      *prometheus.Desc
 	HasDownloads     *prometheus.Desc
+	Template         *prometheus.Desc
+	Info             *prometheus.Desc
+	Topic            *prometheus.Desc
+	LanguageBytes    *prometheus.Desc
 	Pushed           *prometheus.Desc
 	Created          *prometheus.Desc
 	Updated          *prometheus.Desc
@@ -194,6 +198,30 @@ func NewRepoCollector(logger log.Logger, client *github.Client, failures *promet
 			labels,
 			nil,
 		),
+		Template: prometheus.NewDesc(
+			"github_repo_template",
+			"Show if this repository is a template repository",
+			labels,
+			nil,
+		),
+		Info: prometheus.NewDesc(
+			"github_repo_info",
+			"Information about the default branch, visibility and license of repo",
+			append(labels, "default_branch", "visibility", "license"),
+			nil,
+		),
+		Topic: prometheus.NewDesc(
+			"github_repo_topic",
+			"Topics assigned to this repository",
+			append(labels, "topic"),
+			nil,
+		),
+		LanguageBytes: prometheus.NewDesc(
+			"github_repo_language_bytes",
+			"Number of bytes written in a language within this repository",
+			append(labels, "language"),
+			nil,
+		),
 	}
 }
 
@@ -219,6 +247,10 @@ func (c *RepoCollector) Metrics() []*prometheus.Desc {
 		c.HasPages,
 		c.HasProjects,
 		c.HasDownloads,
+		c.Template,
+		c.Info,
+		c.Topic,
+		c.LanguageBytes,
 		c.Pushed,
 		c.Created,
 		c.Updated,
@@ -246,6 +278,10 @@ func (c *RepoCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.HasPages
 	ch <- c.HasProjects
 	ch <- c.HasDownloads
+	ch <- c.Template
+	ch <- c.Info
+	ch <- c.Topic
+	ch <- c.LanguageBytes
 	ch <- c.Pushed
 	ch <- c.Created
 	ch <- c.Updated
@@ -272,7 +308,7 @@ func (c *RepoCollector) Collect(ch chan<- prometheus.Metric) {
 		defer cancel()
 
 		now := time.Now()
-		records, err := c.reposByOwnerAndName(ctx, owner, repo)
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
 		c.duration.WithLabelValues("repo").Observe(time.Since(now).Seconds())
 
 		if err != nil {
@@ -466,6 +502,40 @@ func (c *RepoCollector) Collect(ch chan<- prometheus.Metric) {
 				)
 			}
 
+			if record.IsTemplate != nil {
+				ch <- prometheus.MustNewConstMetric(
+					c.Template,
+					prometheus.GaugeValue,
+					boolToFloat64(*record.IsTemplate),
+					labels...,
+				)
+			}
+
+			visibility := record.GetVisibility()
+			if visibility == "" {
+				visibility = "public"
+
+				if record.GetPrivate() {
+					visibility = "private"
+				}
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.Info,
+				prometheus.GaugeValue,
+				1.0,
+				append(labels, record.GetDefaultBranch(), visibility, record.GetLicense().GetSPDXID())...,
+			)
+
+			for _, topic := range record.Topics {
+				ch <- prometheus.MustNewConstMetric(
+					c.Topic,
+					prometheus.GaugeValue,
+					1.0,
+					append(labels, topic)...,
+				)
+			}
+
 			ch <- prometheus.MustNewConstMetric(
 				c.Pushed,
 				prometheus.GaugeValue,
@@ -499,11 +569,41 @@ func (c *RepoCollector) Collect(ch chan<- prometheus.Metric) {
 				watchers,
 				size,
 			)
+
+			if !c.config.Languages.Enabled {
+				continue
+			}
+
+			now := time.Now()
+			languages, _, err := c.client.Repositories.ListLanguages(ctx, owner, *record.Name)
+			c.duration.WithLabelValues("repo").Observe(time.Since(now).Seconds())
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch languages",
+					"name", *record.FullName,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("repo").Inc()
+				continue
+			}
+
+			for language, bytes := range languages {
+				ch <- prometheus.MustNewConstMetric(
+					c.LanguageBytes,
+					prometheus.GaugeValue,
+					float64(bytes),
+					append(labels, language)...,
+				)
+			}
 		}
 	}
 }
 
-func (c *RepoCollector) reposByOwnerAndName(ctx context.Context, owner, repo string) ([]*github.Repository, error) {
+// reposByOwnerAndName resolves the configured repo name, which could contain
+// a wildcard, to the matching list of repositories.
+func reposByOwnerAndName(ctx context.Context, client *github.Client, owner, repo string) ([]*github.Repository, error) {
 	if strings.Contains(repo, "*") {
 		opts := &github.SearchOptions{
 			ListOptions: github.ListOptions{
@@ -516,7 +616,7 @@ func (c *RepoCollector) reposByOwnerAndName(ctx context.Context, owner, repo str
 		)
 
 		for {
-			result, resp, err := c.client.Search.Repositories(
+			result, resp, err := client.Search.Repositories(
 				ctx,
 				fmt.Sprintf("user:%s", owner),
 				opts,
@@ -541,7 +641,7 @@ func (c *RepoCollector) reposByOwnerAndName(ctx context.Context, owner, repo str
 		return repos, nil
 	}
 
-	res, _, err := c.client.Repositories.Get(ctx, owner, repo)
+	res, _, err := client.Repositories.Get(ctx, owner, repo)
 
 	if err != nil {
 		return nil, err
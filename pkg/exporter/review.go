@@ -0,0 +1,351 @@
+package exporter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+var (
+	durationBuckets = []float64{
+		(1 * time.Hour).Seconds(),
+		(4 * time.Hour).Seconds(),
+		(12 * time.Hour).Seconds(),
+		(24 * time.Hour).Seconds(),
+		(2 * 24 * time.Hour).Seconds(),
+		(4 * 24 * time.Hour).Seconds(),
+		(7 * 24 * time.Hour).Seconds(),
+		(14 * 24 * time.Hour).Seconds(),
+		(30 * 24 * time.Hour).Seconds(),
+	}
+
+	roundBuckets = []float64{1, 2, 3, 4, 5, 7, 10}
+)
+
+// ReviewCollector collects metrics about the review flow of merged pull requests.
+type ReviewCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	TimeToFirstReview *prometheus.Desc
+	TimeToApproval    *prometheus.Desc
+	TimeToMerge       *prometheus.Desc
+	Rounds            *prometheus.Desc
+}
+
+// NewReviewCollector returns a new ReviewCollector.
+func NewReviewCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *ReviewCollector {
+	if failures != nil {
+		failures.WithLabelValues("review").Add(0)
+	}
+
+	labels := []string{"owner", "name"}
+	return &ReviewCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "review"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		TimeToFirstReview: prometheus.NewDesc(
+			"github_review_time_to_first_review_seconds",
+			"Histogram of the time from ready for review to the first review of merged pull requests",
+			labels,
+			nil,
+		),
+		TimeToApproval: prometheus.NewDesc(
+			"github_review_time_to_approval_seconds",
+			"Histogram of the time from ready for review to the first approval of merged pull requests",
+			labels,
+			nil,
+		),
+		TimeToMerge: prometheus.NewDesc(
+			"github_review_time_to_merge_seconds",
+			"Histogram of the time from ready for review to the merge of merged pull requests",
+			labels,
+			nil,
+		),
+		Rounds: prometheus.NewDesc(
+			"github_review_rounds",
+			"Histogram of the review rounds, reviewed distinct commits, of merged pull requests",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *ReviewCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.TimeToFirstReview,
+		c.TimeToApproval,
+		c.TimeToMerge,
+		c.Rounds,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *ReviewCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.TimeToFirstReview
+	ch <- c.TimeToApproval
+	ch <- c.TimeToMerge
+	ch <- c.Rounds
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *ReviewCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("review").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("review").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("review").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			c.collectRepo(ctx, ch, owner, *record.Name, record.GetDefaultBranch())
+		}
+	}
+}
+
+func (c *ReviewCollector) collectRepo(ctx context.Context, ch chan<- prometheus.Metric, owner, repo, branch string) {
+	now := time.Now()
+	pulls, err := mergedPullRequestsSince(ctx, c.client, owner, repo, branch, time.Now().Add(-c.config.Reviews.Window))
+	c.duration.WithLabelValues("review").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch pull requests",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("review").Inc()
+		return
+	}
+
+	var (
+		firstReview []float64
+		approval    []float64
+		merge       []float64
+		rounds      []float64
+	)
+
+	for _, pull := range pulls {
+		if c.config.Reviews.ExcludeBots && isBot(pull.User) {
+			continue
+		}
+
+		ready, err := c.readyForReview(ctx, owner, repo, pull)
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch pull request timeline",
+				"owner", owner,
+				"name", repo,
+				"number", pull.GetNumber(),
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("review").Inc()
+			continue
+		}
+
+		now := time.Now()
+		reviews, _, err := c.client.PullRequests.ListReviews(ctx, owner, repo, pull.GetNumber(), &github.ListOptions{
+			PerPage: 100,
+		})
+		c.duration.WithLabelValues("review").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch pull request reviews",
+				"owner", owner,
+				"name", repo,
+				"number", pull.GetNumber(),
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("review").Inc()
+			continue
+		}
+
+		merge = append(merge, positiveSeconds(pull.GetMergedAt().Sub(ready)))
+
+		var (
+			first    time.Time
+			approved time.Time
+		)
+
+		commits := make(map[string]bool)
+
+		for _, review := range reviews {
+			if review.SubmittedAt == nil || review.GetState() == "PENDING" {
+				continue
+			}
+
+			if review.User != nil && review.User.GetLogin() == pull.GetUser().GetLogin() {
+				continue
+			}
+
+			if c.config.Reviews.ExcludeBots && isBot(review.User) {
+				continue
+			}
+
+			commits[review.GetCommitID()] = true
+
+			if first.IsZero() || review.SubmittedAt.Before(first) {
+				first = *review.SubmittedAt
+			}
+
+			if review.GetState() == "APPROVED" && (approved.IsZero() || review.SubmittedAt.Before(approved)) {
+				approved = *review.SubmittedAt
+			}
+		}
+
+		if !first.IsZero() {
+			firstReview = append(firstReview, positiveSeconds(first.Sub(ready)))
+		}
+
+		if !approved.IsZero() {
+			approval = append(approval, positiveSeconds(approved.Sub(ready)))
+		}
+
+		rounds = append(rounds, float64(len(commits)))
+	}
+
+	labels := []string{
+		owner,
+		repo,
+	}
+
+	c.histogram(ch, c.TimeToFirstReview, durationBuckets, firstReview, labels)
+	c.histogram(ch, c.TimeToApproval, durationBuckets, approval, labels)
+	c.histogram(ch, c.TimeToMerge, durationBuckets, merge, labels)
+	c.histogram(ch, c.Rounds, roundBuckets, rounds, labels)
+}
+
+func (c *ReviewCollector) histogram(ch chan<- prometheus.Metric, desc *prometheus.Desc, buckets, values []float64, labels []string) {
+	count, sum, counts := histogramBuckets(buckets, values)
+
+	ch <- prometheus.MustNewConstHistogram(
+		desc,
+		count,
+		sum,
+		counts,
+		labels...,
+	)
+}
+
+// readyForReview returns the last time the pull request has been marked as
+// ready for review, or the creation if it never has been a draft.
+func (c *ReviewCollector) readyForReview(ctx context.Context, owner, repo string, pull *github.PullRequest) (time.Time, error) {
+	ready := pull.GetCreatedAt()
+
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	for {
+		now := time.Now()
+		events, resp, err := c.client.Issues.ListIssueTimeline(ctx, owner, repo, pull.GetNumber(), opts)
+		c.duration.WithLabelValues("review").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			return ready, err
+		}
+
+		for _, event := range events {
+			if event.GetEvent() == "ready_for_review" && event.CreatedAt != nil && event.CreatedAt.After(ready) {
+				ready = *event.CreatedAt
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return ready, nil
+}
+
+// histogramBuckets calculates the cumulative bucket counts for a constant
+// histogram from the raw values.
+func histogramBuckets(buckets, values []float64) (uint64, float64, map[float64]uint64) {
+	counts := make(map[float64]uint64, len(buckets))
+	sum := 0.0
+
+	for _, bucket := range buckets {
+		counts[bucket] = 0
+	}
+
+	for _, value := range values {
+		sum += value
+
+		for _, bucket := range buckets {
+			if value <= bucket {
+				counts[bucket]++
+			}
+		}
+	}
+
+	return uint64(len(values)), sum, counts
+}
+
+func positiveSeconds(d time.Duration) float64 {
+	if d < 0 {
+		return 0
+	}
+
+	return d.Seconds()
+}
+
+func isBot(user *github.User) bool {
+	if user == nil {
+		return false
+	}
+
+	return user.GetType() == "Bot" || strings.HasSuffix(user.GetLogin(), "[bot]")
+}
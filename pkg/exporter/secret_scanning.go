@@ -0,0 +1,284 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// SecretScanningCollector collects metrics about the secret scanning alerts.
+type SecretScanningCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	OpenAlerts     *prometheus.Desc
+	ResolvedAlerts *prometheus.Desc
+	Bypasses       *prometheus.Desc
+}
+
+// NewSecretScanningCollector returns a new SecretScanningCollector.
+func NewSecretScanningCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *SecretScanningCollector {
+	if failures != nil {
+		failures.WithLabelValues("secret_scanning").Add(0)
+	}
+
+	labels := []string{"type", "name"}
+	return &SecretScanningCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "secret_scanning"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		OpenAlerts: prometheus.NewDesc(
+			"github_secret_scanning_open_alerts",
+			"Number of open secret scanning alerts for this type by secret type",
+			append(labels, "secret_type"),
+			nil,
+		),
+		ResolvedAlerts: prometheus.NewDesc(
+			"github_secret_scanning_resolved_alerts",
+			"Number of resolved secret scanning alerts for this type by resolution",
+			append(labels, "resolution"),
+			nil,
+		),
+		Bypasses: prometheus.NewDesc(
+			"github_secret_scanning_push_protection_bypasses",
+			"Number of secret scanning alerts for this type where push protection got bypassed",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *SecretScanningCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.OpenAlerts,
+		c.ResolvedAlerts,
+		c.Bypasses,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *SecretScanningCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.OpenAlerts
+	ch <- c.ResolvedAlerts
+	ch <- c.Bypasses
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *SecretScanningCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Enterprises.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := c.alerts(ctx, fmt.Sprintf("enterprises/%s/secret-scanning/alerts", name))
+		c.duration.WithLabelValues("secret_scanning").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch alerts",
+				"type", "enterprise",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("secret_scanning").Inc()
+			continue
+		}
+
+		c.export(ch, records, "enterprise", name)
+	}
+
+	for _, name := range c.config.Orgs.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := c.alerts(ctx, fmt.Sprintf("orgs/%s/secret-scanning/alerts", name))
+		c.duration.WithLabelValues("secret_scanning").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch alerts",
+				"type", "org",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("secret_scanning").Inc()
+			continue
+		}
+
+		c.export(ch, records, "org", name)
+	}
+
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("secret_scanning").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		repos, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("secret_scanning").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("secret_scanning").Inc()
+			continue
+		}
+
+		for _, record := range repos {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			now := time.Now()
+			records, err := c.alerts(ctx, fmt.Sprintf("repos/%s/%s/secret-scanning/alerts", owner, *record.Name))
+			c.duration.WithLabelValues("secret_scanning").Observe(time.Since(now).Seconds())
+
+			if isNotEnabled(err) {
+				level.Debug(c.logger).Log(
+					"msg", "Secret scanning is not enabled",
+					"name", *record.FullName,
+				)
+
+				continue
+			}
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch alerts",
+					"type", "repo",
+					"name", *record.FullName,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("secret_scanning").Inc()
+				continue
+			}
+
+			c.export(ch, records, "repo", fmt.Sprintf("%s/%s", owner, *record.Name))
+		}
+	}
+}
+
+func (c *SecretScanningCollector) export(ch chan<- prometheus.Metric, records []*secretScanningAlert, kind, name string) {
+	open := make(map[string]int)
+	resolved := make(map[string]int)
+	bypasses := 0
+
+	for _, record := range records {
+		switch record.State {
+		case "open":
+			open[record.SecretType]++
+		case "resolved":
+			resolved[record.Resolution]++
+		}
+
+		if record.PushProtectionBypassed {
+			bypasses++
+		}
+	}
+
+	for secretType, count := range open {
+		ch <- prometheus.MustNewConstMetric(
+			c.OpenAlerts,
+			prometheus.GaugeValue,
+			float64(count),
+			kind,
+			name,
+			secretType,
+		)
+	}
+
+	for resolution, count := range resolved {
+		ch <- prometheus.MustNewConstMetric(
+			c.ResolvedAlerts,
+			prometheus.GaugeValue,
+			float64(count),
+			kind,
+			name,
+			resolution,
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Bypasses,
+		prometheus.GaugeValue,
+		float64(bypasses),
+		kind,
+		name,
+	)
+}
+
+func (c *SecretScanningCollector) alerts(ctx context.Context, path string) ([]*secretScanningAlert, error) {
+	var (
+		result []*secretScanningAlert
+	)
+
+	path = path + "?per_page=100"
+
+	for path != "" {
+		req, err := c.client.NewRequest("GET", path, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]*secretScanningAlert, 0)
+		resp, err := c.client.Do(ctx, req, &records)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(
+			result,
+			records...,
+		)
+
+		path = nextPageURL(resp)
+	}
+
+	return result, nil
+}
+
+type secretScanningAlert struct {
+	Number                 int    `json:"number"`
+	State                  string `json:"state"`
+	Resolution             string `json:"resolution"`
+	SecretType             string `json:"secret_type"`
+	PushProtectionBypassed bool   `json:"push_protection_bypassed"`
+}
@@ -0,0 +1,345 @@
+package exporter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// StatsCollector collects metrics about the repository statistics.
+type StatsCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	mutex sync.Mutex
+	cache map[string]*statsRecord
+
+	Contributors       *prometheus.Desc
+	WeeklyCommits      *prometheus.Desc
+	YearlyCommits      *prometheus.Desc
+	WeeklyAdditions    *prometheus.Desc
+	WeeklyDeletions    *prometheus.Desc
+	ParticipationAll   *prometheus.Desc
+	ParticipationOwner *prometheus.Desc
+}
+
+// statsRecord keeps the last successful responses for a repository, GitHub
+// responds with 202 while it computes the statistics in the background.
+type statsRecord struct {
+	contributors  []*github.ContributorStats
+	activity      []*github.WeeklyCommitActivity
+	frequency     []*github.WeeklyStats
+	participation *github.RepositoryParticipation
+}
+
+// NewStatsCollector returns a new StatsCollector.
+func NewStatsCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *StatsCollector {
+	if failures != nil {
+		failures.WithLabelValues("stats").Add(0)
+	}
+
+	labels := []string{"owner", "name"}
+	return &StatsCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "stats"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+		cache:    make(map[string]*statsRecord),
+
+		Contributors: prometheus.NewDesc(
+			"github_stats_contributors",
+			"Number of contributors to this repository",
+			labels,
+			nil,
+		),
+		WeeklyCommits: prometheus.NewDesc(
+			"github_stats_weekly_commits",
+			"Number of commits within the current week",
+			labels,
+			nil,
+		),
+		YearlyCommits: prometheus.NewDesc(
+			"github_stats_yearly_commits",
+			"Number of commits within the last 52 weeks",
+			labels,
+			nil,
+		),
+		WeeklyAdditions: prometheus.NewDesc(
+			"github_stats_weekly_additions",
+			"Number of added lines within the current week",
+			labels,
+			nil,
+		),
+		WeeklyDeletions: prometheus.NewDesc(
+			"github_stats_weekly_deletions",
+			"Number of deleted lines within the current week",
+			labels,
+			nil,
+		),
+		ParticipationAll: prometheus.NewDesc(
+			"github_stats_participation_all",
+			"Number of commits by everyone within the last week",
+			labels,
+			nil,
+		),
+		ParticipationOwner: prometheus.NewDesc(
+			"github_stats_participation_owner",
+			"Number of commits by the owner within the last week",
+			labels,
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *StatsCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Contributors,
+		c.WeeklyCommits,
+		c.YearlyCommits,
+		c.WeeklyAdditions,
+		c.WeeklyDeletions,
+		c.ParticipationAll,
+		c.ParticipationOwner,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *StatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Contributors
+	ch <- c.WeeklyCommits
+	ch <- c.YearlyCommits
+	ch <- c.WeeklyAdditions
+	ch <- c.WeeklyDeletions
+	ch <- c.ParticipationAll
+	ch <- c.ParticipationOwner
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *StatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("stats").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("stats").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("stats").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			labels := []string{
+				owner,
+				*record.Name,
+			}
+
+			stats := c.fetch(ctx, owner, *record.Name)
+
+			if stats.contributors != nil {
+				ch <- prometheus.MustNewConstMetric(
+					c.Contributors,
+					prometheus.GaugeValue,
+					float64(len(stats.contributors)),
+					labels...,
+				)
+			}
+
+			if len(stats.activity) > 0 {
+				yearly := 0
+
+				for _, week := range stats.activity {
+					yearly += week.GetTotal()
+				}
+
+				ch <- prometheus.MustNewConstMetric(
+					c.WeeklyCommits,
+					prometheus.GaugeValue,
+					float64(stats.activity[len(stats.activity)-1].GetTotal()),
+					labels...,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					c.YearlyCommits,
+					prometheus.GaugeValue,
+					float64(yearly),
+					labels...,
+				)
+			}
+
+			if len(stats.frequency) > 0 {
+				week := stats.frequency[len(stats.frequency)-1]
+
+				ch <- prometheus.MustNewConstMetric(
+					c.WeeklyAdditions,
+					prometheus.GaugeValue,
+					float64(week.GetAdditions()),
+					labels...,
+				)
+
+				// GitHub reports deletions as negative numbers.
+				deletions := week.GetDeletions()
+
+				if deletions < 0 {
+					deletions = -deletions
+				}
+
+				ch <- prometheus.MustNewConstMetric(
+					c.WeeklyDeletions,
+					prometheus.GaugeValue,
+					float64(deletions),
+					labels...,
+				)
+			}
+
+			if stats.participation != nil {
+				if len(stats.participation.All) > 0 {
+					ch <- prometheus.MustNewConstMetric(
+						c.ParticipationAll,
+						prometheus.GaugeValue,
+						float64(stats.participation.All[len(stats.participation.All)-1]),
+						labels...,
+					)
+				}
+
+				if len(stats.participation.Owner) > 0 {
+					ch <- prometheus.MustNewConstMetric(
+						c.ParticipationOwner,
+						prometheus.GaugeValue,
+						float64(stats.participation.Owner[len(stats.participation.Owner)-1]),
+						labels...,
+					)
+				}
+			}
+		}
+	}
+}
+
+// fetch requests all statistics for a repository. If GitHub is still
+// computing a statistic it falls back to the last known response, the next
+// refresh will pick up the computed result.
+func (c *StatsCollector) fetch(ctx context.Context, owner, repo string) *statsRecord {
+	key := owner + "/" + repo
+	cached, ok := c.cache[key]
+
+	if !ok {
+		cached = &statsRecord{}
+		c.cache[key] = cached
+	}
+
+	{
+		now := time.Now()
+		result, _, err := c.client.Repositories.ListContributorsStats(ctx, owner, repo)
+		c.duration.WithLabelValues("stats").Observe(time.Since(now).Seconds())
+
+		if c.handle(err, owner, repo, "contributors") {
+			cached.contributors = result
+		}
+	}
+
+	{
+		now := time.Now()
+		result, _, err := c.client.Repositories.ListCommitActivity(ctx, owner, repo)
+		c.duration.WithLabelValues("stats").Observe(time.Since(now).Seconds())
+
+		if c.handle(err, owner, repo, "commit_activity") {
+			cached.activity = result
+		}
+	}
+
+	{
+		now := time.Now()
+		result, _, err := c.client.Repositories.ListCodeFrequency(ctx, owner, repo)
+		c.duration.WithLabelValues("stats").Observe(time.Since(now).Seconds())
+
+		if c.handle(err, owner, repo, "code_frequency") {
+			cached.frequency = result
+		}
+	}
+
+	{
+		now := time.Now()
+		result, _, err := c.client.Repositories.ListParticipation(ctx, owner, repo)
+		c.duration.WithLabelValues("stats").Observe(time.Since(now).Seconds())
+
+		if c.handle(err, owner, repo, "participation") {
+			cached.participation = result
+		}
+	}
+
+	return cached
+}
+
+// handle reports if the response should replace the cached statistic. A 202
+// response is not treated as a failure, it just keeps the cached value.
+func (c *StatsCollector) handle(err error, owner, repo, stat string) bool {
+	if err == nil {
+		return true
+	}
+
+	var accepted *github.AcceptedError
+
+	if errors.As(err, &accepted) {
+		level.Debug(c.logger).Log(
+			"msg", "Statistics are still computed",
+			"owner", owner,
+			"name", repo,
+			"stat", stat,
+		)
+
+		return false
+	}
+
+	level.Error(c.logger).Log(
+		"msg", "Failed to fetch statistics",
+		"owner", owner,
+		"name", repo,
+		"stat", stat,
+		"err", err,
+	)
+
+	c.failures.WithLabelValues("stats").Inc()
+	return false
+}
@@ -0,0 +1,326 @@
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+)
+
+// TeamCollector collects metrics about the teams within orgs.
+type TeamCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Members     *prometheus.Desc
+	Maintainers *prometheus.Desc
+	Repos       *prometheus.Desc
+	Repo        *prometheus.Desc
+	Parent      *prometheus.Desc
+	Privacy     *prometheus.Desc
+}
+
+// NewTeamCollector returns a new TeamCollector.
+func NewTeamCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *TeamCollector {
+	if failures != nil {
+		failures.WithLabelValues("team").Add(0)
+	}
+
+	labels := []string{"org", "team"}
+	return &TeamCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "team"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Members: prometheus.NewDesc(
+			"github_team_members",
+			"Number of members within the team",
+			labels,
+			nil,
+		),
+		Maintainers: prometheus.NewDesc(
+			"github_team_maintainers",
+			"Number of maintainers within the team",
+			labels,
+			nil,
+		),
+		Repos: prometheus.NewDesc(
+			"github_team_repos",
+			"Number of repositories the team has access to by permission",
+			append(labels, "permission"),
+			nil,
+		),
+		Repo: prometheus.NewDesc(
+			"github_team_repo_permission",
+			"Permission of the team on the repository",
+			append(labels, "repo", "permission"),
+			nil,
+		),
+		Parent: prometheus.NewDesc(
+			"github_team_parent",
+			"Parent of the nested team",
+			append(labels, "parent"),
+			nil,
+		),
+		Privacy: prometheus.NewDesc(
+			"github_team_privacy",
+			"Privacy of the team",
+			append(labels, "privacy"),
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *TeamCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Members,
+		c.Maintainers,
+		c.Repos,
+		c.Repo,
+		c.Parent,
+		c.Privacy,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *TeamCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Members
+	ch <- c.Maintainers
+	ch <- c.Repos
+	ch <- c.Repo
+	ch <- c.Parent
+	ch <- c.Privacy
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *TeamCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Orgs.Value() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		teams, err := c.teams(ctx, name)
+		c.duration.WithLabelValues("team").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch teams",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("team").Inc()
+			continue
+		}
+
+		for _, team := range teams {
+			if team.Slug == nil {
+				continue
+			}
+
+			labels := []string{
+				name,
+				*team.Slug,
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				c.Privacy,
+				prometheus.GaugeValue,
+				1.0,
+				append(labels, team.GetPrivacy())...,
+			)
+
+			if team.Parent != nil {
+				ch <- prometheus.MustNewConstMetric(
+					c.Parent,
+					prometheus.GaugeValue,
+					1.0,
+					append(labels, team.Parent.GetSlug())...,
+				)
+			}
+
+			for _, role := range []string{"all", "maintainer"} {
+				now := time.Now()
+				count, err := c.members(ctx, name, *team.Slug, role)
+				c.duration.WithLabelValues("team").Observe(time.Since(now).Seconds())
+
+				if err != nil {
+					level.Error(c.logger).Log(
+						"msg", "Failed to fetch team members",
+						"name", name,
+						"team", *team.Slug,
+						"role", role,
+						"err", err,
+					)
+
+					c.failures.WithLabelValues("team").Inc()
+					continue
+				}
+
+				desc := c.Members
+				if role == "maintainer" {
+					desc = c.Maintainers
+				}
+
+				ch <- prometheus.MustNewConstMetric(
+					desc,
+					prometheus.GaugeValue,
+					float64(count),
+					labels...,
+				)
+			}
+
+			now := time.Now()
+			repos, err := c.repos(ctx, name, *team.Slug)
+			c.duration.WithLabelValues("team").Observe(time.Since(now).Seconds())
+
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", "Failed to fetch team repos",
+					"name", name,
+					"team", *team.Slug,
+					"err", err,
+				)
+
+				c.failures.WithLabelValues("team").Inc()
+				continue
+			}
+
+			permissions := make(map[string]int)
+
+			for _, repo := range repos {
+				permission := highestPermission(repo.Permissions)
+				permissions[permission]++
+
+				ch <- prometheus.MustNewConstMetric(
+					c.Repo,
+					prometheus.GaugeValue,
+					1.0,
+					append(labels, repo.GetFullName(), permission)...,
+				)
+			}
+
+			for permission, count := range permissions {
+				ch <- prometheus.MustNewConstMetric(
+					c.Repos,
+					prometheus.GaugeValue,
+					float64(count),
+					append(labels, permission)...,
+				)
+			}
+		}
+	}
+}
+
+func (c *TeamCollector) teams(ctx context.Context, org string) ([]*github.Team, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var (
+		teams []*github.Team
+	)
+
+	for {
+		result, resp, err := c.client.Teams.ListTeams(ctx, org, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(
+			teams,
+			result...,
+		)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return teams, nil
+}
+
+func (c *TeamCollector) members(ctx context.Context, org, slug, role string) (int, error) {
+	opts := &github.TeamListTeamMembersOptions{
+		Role: role,
+		ListOptions: github.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	count := 0
+
+	for {
+		result, resp, err := c.client.Teams.ListTeamMembersBySlug(ctx, org, slug, opts)
+
+		if err != nil {
+			return 0, err
+		}
+
+		count += len(result)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return count, nil
+}
+
+func (c *TeamCollector) repos(ctx context.Context, org, slug string) ([]*github.Repository, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var (
+		repos []*github.Repository
+	)
+
+	for {
+		result, resp, err := c.client.Teams.ListTeamReposBySlug(ctx, org, slug, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		repos = append(
+			repos,
+			result...,
+		)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return repos, nil
+}
+
+// highestPermission maps the permission flags of the API to the highest
+// granted permission level.
+func highestPermission(permissions map[string]bool) string {
+	for _, permission := range []string{"admin", "maintain", "push", "triage", "pull"} {
+		if permissions[permission] {
+			return permission
+		}
+	}
+
+	return "none"
+}
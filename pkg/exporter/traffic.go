@@ -0,0 +1,386 @@
+package exporter
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/google/go-github/v35/github"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/promhippie/github_exporter/pkg/config"
+	"github.com/ryanuber/go-glob"
+)
+
+// TrafficCollector collects metrics about the repository traffic.
+type TrafficCollector struct {
+	client   *github.Client
+	logger   log.Logger
+	failures *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	config   config.Target
+
+	Views               *prometheus.Desc
+	ViewsUnique         *prometheus.Desc
+	Clones              *prometheus.Desc
+	ClonesUnique        *prometheus.Desc
+	DailyViews          *prometheus.Desc
+	DailyViewsUnique    *prometheus.Desc
+	DailyClones         *prometheus.Desc
+	DailyClonesUnique   *prometheus.Desc
+	ReferrerViews       *prometheus.Desc
+	ReferrerViewsUnique *prometheus.Desc
+	PathViews           *prometheus.Desc
+	PathViewsUnique     *prometheus.Desc
+}
+
+// NewTrafficCollector returns a new TrafficCollector.
+func NewTrafficCollector(logger log.Logger, client *github.Client, failures *prometheus.CounterVec, duration *prometheus.HistogramVec, cfg config.Target) *TrafficCollector {
+	if failures != nil {
+		failures.WithLabelValues("traffic").Add(0)
+	}
+
+	labels := []string{"owner", "name"}
+	return &TrafficCollector{
+		client:   client,
+		logger:   log.With(logger, "collector", "traffic"),
+		failures: failures,
+		duration: duration,
+		config:   cfg,
+
+		Views: prometheus.NewDesc(
+			"github_traffic_views",
+			"Number of views within the last 14 days",
+			labels,
+			nil,
+		),
+		ViewsUnique: prometheus.NewDesc(
+			"github_traffic_views_unique",
+			"Number of unique visitors within the last 14 days",
+			labels,
+			nil,
+		),
+		Clones: prometheus.NewDesc(
+			"github_traffic_clones",
+			"Number of clones within the last 14 days",
+			labels,
+			nil,
+		),
+		ClonesUnique: prometheus.NewDesc(
+			"github_traffic_clones_unique",
+			"Number of unique cloners within the last 14 days",
+			labels,
+			nil,
+		),
+		DailyViews: prometheus.NewDesc(
+			"github_traffic_daily_views",
+			"Number of views within the day bucket, labeled by the day in UTC",
+			append(labels, "day"),
+			nil,
+		),
+		DailyViewsUnique: prometheus.NewDesc(
+			"github_traffic_daily_views_unique",
+			"Number of unique visitors within the day bucket, labeled by the day in UTC",
+			append(labels, "day"),
+			nil,
+		),
+		DailyClones: prometheus.NewDesc(
+			"github_traffic_daily_clones",
+			"Number of clones within the day bucket, labeled by the day in UTC",
+			append(labels, "day"),
+			nil,
+		),
+		DailyClonesUnique: prometheus.NewDesc(
+			"github_traffic_daily_clones_unique",
+			"Number of unique cloners within the day bucket, labeled by the day in UTC",
+			append(labels, "day"),
+			nil,
+		),
+		ReferrerViews: prometheus.NewDesc(
+			"github_traffic_referrer_views",
+			"Number of views from a top referrer within the last 14 days",
+			append(labels, "referrer"),
+			nil,
+		),
+		ReferrerViewsUnique: prometheus.NewDesc(
+			"github_traffic_referrer_views_unique",
+			"Number of unique visitors from a top referrer within the last 14 days",
+			append(labels, "referrer"),
+			nil,
+		),
+		PathViews: prometheus.NewDesc(
+			"github_traffic_path_views",
+			"Number of views of a popular content path within the last 14 days",
+			append(labels, "path"),
+			nil,
+		),
+		PathViewsUnique: prometheus.NewDesc(
+			"github_traffic_path_views_unique",
+			"Number of unique visitors of a popular content path within the last 14 days",
+			append(labels, "path"),
+			nil,
+		),
+	}
+}
+
+// Metrics simply returns the list metric descriptors for generating a documentation.
+func (c *TrafficCollector) Metrics() []*prometheus.Desc {
+	return []*prometheus.Desc{
+		c.Views,
+		c.ViewsUnique,
+		c.Clones,
+		c.ClonesUnique,
+		c.DailyViews,
+		c.DailyViewsUnique,
+		c.DailyClones,
+		c.DailyClonesUnique,
+		c.ReferrerViews,
+		c.ReferrerViewsUnique,
+		c.PathViews,
+		c.PathViewsUnique,
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics collected by this Collector.
+func (c *TrafficCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Views
+	ch <- c.ViewsUnique
+	ch <- c.Clones
+	ch <- c.ClonesUnique
+	ch <- c.DailyViews
+	ch <- c.DailyViewsUnique
+	ch <- c.DailyClones
+	ch <- c.DailyClonesUnique
+	ch <- c.ReferrerViews
+	ch <- c.ReferrerViewsUnique
+	ch <- c.PathViews
+	ch <- c.PathViewsUnique
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *TrafficCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, name := range c.config.Repos.Value() {
+		n := strings.Split(name, "/")
+
+		if len(n) != 2 {
+			level.Error(c.logger).Log(
+				"msg", "Invalid repo name",
+				"name", name,
+			)
+
+			c.failures.WithLabelValues("traffic").Inc()
+			continue
+		}
+
+		owner, repo := n[0], n[1]
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+		defer cancel()
+
+		now := time.Now()
+		records, err := reposByOwnerAndName(ctx, c.client, owner, repo)
+		c.duration.WithLabelValues("traffic").Observe(time.Since(now).Seconds())
+
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", "Failed to fetch repos",
+				"name", name,
+				"err", err,
+			)
+
+			c.failures.WithLabelValues("traffic").Inc()
+			continue
+		}
+
+		for _, record := range records {
+			if !glob.Glob(name, *record.FullName) {
+				continue
+			}
+
+			labels := []string{
+				owner,
+				*record.Name,
+			}
+
+			c.collectViews(ctx, ch, owner, *record.Name, labels)
+			c.collectClones(ctx, ch, owner, *record.Name, labels)
+			c.collectReferrers(ctx, ch, owner, *record.Name, labels)
+			c.collectPaths(ctx, ch, owner, *record.Name, labels)
+		}
+	}
+}
+
+func (c *TrafficCollector) collectViews(ctx context.Context, ch chan<- prometheus.Metric, owner, repo string, labels []string) {
+	now := time.Now()
+	record, _, err := c.client.Repositories.ListTrafficViews(ctx, owner, repo, &github.TrafficBreakdownOptions{
+		Per: "day",
+	})
+	c.duration.WithLabelValues("traffic").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch views",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("traffic").Inc()
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Views,
+		prometheus.GaugeValue,
+		float64(record.GetCount()),
+		labels...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ViewsUnique,
+		prometheus.GaugeValue,
+		float64(record.GetUniques()),
+		labels...,
+	)
+
+	for _, bucket := range record.Views {
+		c.collectBucket(ch, c.DailyViews, c.DailyViewsUnique, bucket, labels)
+	}
+}
+
+func (c *TrafficCollector) collectClones(ctx context.Context, ch chan<- prometheus.Metric, owner, repo string, labels []string) {
+	now := time.Now()
+	record, _, err := c.client.Repositories.ListTrafficClones(ctx, owner, repo, &github.TrafficBreakdownOptions{
+		Per: "day",
+	})
+	c.duration.WithLabelValues("traffic").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch clones",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("traffic").Inc()
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.Clones,
+		prometheus.GaugeValue,
+		float64(record.GetCount()),
+		labels...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.ClonesUnique,
+		prometheus.GaugeValue,
+		float64(record.GetUniques()),
+		labels...,
+	)
+
+	for _, bucket := range record.Clones {
+		c.collectBucket(ch, c.DailyClones, c.DailyClonesUnique, bucket, labels)
+	}
+}
+
+func (c *TrafficCollector) collectBucket(ch chan<- prometheus.Metric, count, uniques *prometheus.Desc, bucket *github.TrafficData, labels []string) {
+	if bucket == nil || bucket.Timestamp == nil {
+		return
+	}
+
+	day := append(labels, bucket.Timestamp.UTC().Format("2006-01-02"))
+
+	ch <- prometheus.MustNewConstMetric(
+		count,
+		prometheus.GaugeValue,
+		float64(bucket.GetCount()),
+		day...,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		uniques,
+		prometheus.GaugeValue,
+		float64(bucket.GetUniques()),
+		day...,
+	)
+}
+
+func (c *TrafficCollector) collectReferrers(ctx context.Context, ch chan<- prometheus.Metric, owner, repo string, labels []string) {
+	now := time.Now()
+	records, _, err := c.client.Repositories.ListTrafficReferrers(ctx, owner, repo)
+	c.duration.WithLabelValues("traffic").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch referrers",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("traffic").Inc()
+		return
+	}
+
+	for _, record := range records {
+		if record == nil || record.Referrer == nil {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.ReferrerViews,
+			prometheus.GaugeValue,
+			float64(record.GetCount()),
+			append(labels, *record.Referrer)...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.ReferrerViewsUnique,
+			prometheus.GaugeValue,
+			float64(record.GetUniques()),
+			append(labels, *record.Referrer)...,
+		)
+	}
+}
+
+func (c *TrafficCollector) collectPaths(ctx context.Context, ch chan<- prometheus.Metric, owner, repo string, labels []string) {
+	now := time.Now()
+	records, _, err := c.client.Repositories.ListTrafficPaths(ctx, owner, repo)
+	c.duration.WithLabelValues("traffic").Observe(time.Since(now).Seconds())
+
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "Failed to fetch paths",
+			"owner", owner,
+			"name", repo,
+			"err", err,
+		)
+
+		c.failures.WithLabelValues("traffic").Inc()
+		return
+	}
+
+	for _, record := range records {
+		if record == nil || record.Path == nil {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			c.PathViews,
+			prometheus.GaugeValue,
+			float64(record.GetCount()),
+			append(labels, *record.Path)...,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.PathViewsUnique,
+			prometheus.GaugeValue,
+			float64(record.GetUniques()),
+			append(labels, *record.Path)...,
+		)
+	}
+}